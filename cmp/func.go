@@ -0,0 +1,109 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// By returns comparator, which compares values by key. It's compatible with
+// slices.SortFunc and other functions, accepting cmp func(a, b T) int:
+//
+//	slices.SortFunc(users, cmp.By(func(u User) string { return u.Name }))
+func By[T any, K Ordered](key func(T) K) func(a, b T) int {
+	return func(a, b T) int { return Compare(key(a), key(b)) }
+}
+
+// Then combines comparators: values are compared by the first one, if they
+// are equal, then by the second one, and so on. It's useful for multi-key
+// sorting:
+//
+//	cmp.Then(cmp.By(lastName), cmp.By(firstName), cmp.Reverse(cmp.By(age)))
+func Then[T any](cmps ...func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		for _, cmp := range cmps {
+			if c := cmp(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// Reverse returns comparator with reversed order.
+func Reverse[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int { return cmp(b, a) }
+}
+
+// ByFields is the same as Then, named for readability of struct comparators:
+//
+//	cmp.ByFields(cmp.By(func(u User) string { return u.Name }), cmp.By(func(u User) int { return u.Age }))
+func ByFields[T any](cmps ...func(a, b T) int) func(a, b T) int { return Then(cmps...) }
+
+// Fields is an untyped version of ByFields: each extractor returns value of a
+// field, and fields are compared in order. Extracted values must be of any
+// ordered kind (including named types), bool (false < true) or time.Time, nil
+// is ordered before any value. Comparator panics, if extractor returns values
+// of different or unsupported types.
+//
+// Fields is slower than ByFields because of reflection, prefer the latter in hot
+// paths.
+func Fields[T any](extractors ...func(T) any) func(a, b T) int {
+	return func(a, b T) int {
+		for _, field := range extractors {
+			if c := compareAny(field(a), field(b)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+func compareAny(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return Time(ta, tb)
+		}
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		panic(fmt.Sprintf("can't compare values of different types %T and %T", a, b))
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return Compare(va.String(), vb.String())
+	case reflect.Bool:
+		return Compare(boolToInt(va.Bool()), boolToInt(vb.Bool()))
+	default:
+		panic(fmt.Sprintf("can't compare values of type %T", a))
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
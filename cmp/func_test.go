@@ -0,0 +1,156 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+type level int
+
+type user struct {
+	Name    string
+	Age     int
+	Level   level
+	Admin   bool
+	Score   float64
+	Created time.Time
+}
+
+func TestBy(t *testing.T) {
+	byName := By(func(u user) string { return u.Name })
+	byAge := By(func(u user) int { return u.Age })
+
+	for _, tt := range []struct {
+		name string
+		cmp  func(a, b user) int
+		a, b user
+		want int
+	}{
+		{"by", byName, user{Name: "a"}, user{Name: "b"}, -1},
+		{"by equal", byName, user{Name: "a", Age: 1}, user{Name: "a", Age: 2}, 0},
+		{"reverse", Reverse(byName), user{Name: "a"}, user{Name: "b"}, 1},
+		{"then first", Then(byName, byAge), user{Name: "b", Age: 1}, user{Name: "a", Age: 2}, 1},
+		{"then second", Then(byName, byAge), user{Name: "a", Age: 1}, user{Name: "a", Age: 2}, -1},
+		{"then reversed second", Then(byName, Reverse(byAge)), user{Name: "a", Age: 1}, user{Name: "a", Age: 2}, 1},
+		{"then all equal", Then(byName, byAge), user{Name: "a", Age: 1}, user{Name: "a", Age: 1}, 0},
+		{"then empty", Then[user](), user{Name: "a"}, user{Name: "b"}, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cmp(tt.a, tt.b); got != tt.want {
+				t.Errorf("cmp(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByFields(t *testing.T) {
+	byNameAge := ByFields(
+		By(func(u user) string { return u.Name }),
+		Reverse(By(func(u user) int { return u.Age })),
+	)
+
+	for _, tt := range []struct {
+		name string
+		a, b user
+		want int
+	}{
+		{"first field", user{Name: "alice", Age: 10}, user{Name: "bob", Age: 20}, -1},
+		{"second field reversed", user{Name: "alice", Age: 10}, user{Name: "alice", Age: 20}, 1},
+		{"equal", user{Name: "alice", Age: 10}, user{Name: "alice", Age: 10}, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := byNameAge(tt.a, tt.b); got != tt.want {
+				t.Errorf("ByFields(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	now := time.Now()
+
+	for _, tt := range []struct {
+		name  string
+		field func(user) any
+		a, b  user
+		want  int
+	}{
+		{"string", func(u user) any { return u.Name }, user{Name: "a"}, user{Name: "b"}, -1},
+		{"int", func(u user) any { return u.Age }, user{Age: 20}, user{Age: 10}, 1},
+		{"named int", func(u user) any { return u.Level }, user{Level: 1}, user{Level: 2}, -1},
+		{"bool", func(u user) any { return u.Admin }, user{Admin: true}, user{}, 1},
+		{"float", func(u user) any { return u.Score }, user{Score: 0.5}, user{Score: 0.5}, 0},
+		{"time", func(u user) any { return u.Created }, user{Created: now}, user{Created: now.Add(time.Second)}, -1},
+		{"uint", func(u user) any { return uint8(u.Age) }, user{Age: 3}, user{Age: 2}, 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fields(tt.field)(tt.a, tt.b); got != tt.want {
+				t.Errorf("Fields(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("order", func(t *testing.T) {
+		c := Fields(func(u user) any { return u.Name }, func(u user) any { return u.Age })
+		if got := c(user{Name: "a", Age: 2}, user{Name: "a", Age: 1}); got != 1 {
+			t.Errorf("second field must be compared, if first are equal, got %v", got)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		c := Fields(func(u user) any {
+			if u.Name == "" {
+				return nil
+			}
+			return u.Name
+		})
+		for _, tt := range []struct {
+			a, b user
+			want int
+		}{
+			{user{}, user{}, 0},
+			{user{}, user{Name: "a"}, -1},
+			{user{Name: "a"}, user{}, 1},
+		} {
+			if got := c(tt.a, tt.b); got != tt.want {
+				t.Errorf("Fields(%q, %q) = %v, want %v", tt.a.Name, tt.b.Name, got, tt.want)
+			}
+		}
+	})
+
+	for _, tt := range []struct {
+		name  string
+		field func(user) any
+		want  string
+	}{
+		{"different types", func(u user) any {
+			if u.Admin {
+				return 1
+			}
+			return "1"
+		}, "can't compare values of different types int and string"},
+		{"different named types", func(u user) any {
+			if u.Admin {
+				return u.Level
+			}
+			return u.Age
+		}, "can't compare values of different types cmp_test.level and int"},
+		{"unsupported type", func(u user) any { return []int{u.Age} }, "can't compare values of type []int"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != tt.want {
+					t.Errorf("got panic %v, want %q", r, tt.want)
+				}
+			}()
+			Fields(tt.field)(user{Admin: true}, user{})
+		})
+	}
+}
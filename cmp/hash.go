@@ -0,0 +1,57 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+import (
+	"hash/maphash"
+)
+
+// Hasher calculates hash of values. Complementing Eq, equal values must have
+// equal hashes. It's the contract for hash-based containers of non-comparable
+// types, e.g. set.NewFunc(h.Hash, eq) or maps.NewSharded(n, h.Hash).
+type Hasher[T any] interface{ Hash(T) uint64 }
+
+// HasherFunc is an adapter to use ordinary function as Hasher.
+type HasherFunc[T any] func(T) uint64
+
+func (f HasherFunc[T]) Hash(v T) uint64 { return f(v) }
+
+// seed is shared by all hash functions of this package. It's random for each
+// process, so hashes must not be persisted or sent over network.
+var seed = maphash.MakeSeed()
+
+// HashBytes returns hash of b.
+func HashBytes(b []byte) uint64 { return maphash.Bytes(seed, b) }
+
+// HashString returns hash of s. It's equal to HashBytes([]byte(s)).
+func HashString(s string) uint64 { return maphash.String(seed, s) }
+
+// HashInt returns hash of integer value.
+func HashInt[T Integer](v T) uint64 { return mix(uint64(v)) }
+
+// HashCombine combines hashes of several fields into single hash. Order of
+// hashes matters, so HashCombine(a, b) != HashCombine(b, a):
+//
+//	func (p Point) Hash() uint64 { return cmp.HashCombine(cmp.HashInt(p.X), cmp.HashInt(p.Y)) }
+func HashCombine(hashes ...uint64) uint64 {
+	var h uint64 = 0x9E3779B97F4A7C15
+	for _, v := range hashes {
+		h = mix(h ^ (v + 0x9E3779B97F4A7C15 + h<<6 + h>>2))
+	}
+
+	return h
+}
+
+// mix is a finalizer of splitmix64, which spreads bits of x uniformly.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+
+	return x
+}
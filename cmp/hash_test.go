@@ -0,0 +1,63 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"testing"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+func TestHash(t *testing.T) {
+	if HashString("hello") != HashBytes([]byte("hello")) {
+		t.Error("HashString must be equal to HashBytes of same data")
+	}
+	if HashString("hello") == HashString("world") {
+		t.Error("HashString: collision on different strings")
+	}
+	if HashInt(42) != HashInt(int64(42)) || HashInt(uint8(42)) != HashInt(42) {
+		t.Error("HashInt must not depend on integer type")
+	}
+
+	// hashes of small integers must be spread, not sequential
+	seen := make(map[uint64]bool)
+	for i := 0; i < 1000; i++ {
+		seen[HashInt(i)] = true
+	}
+	if len(seen) != 1000 {
+		t.Errorf("HashInt: %v collisions on 1000 small integers", 1000-len(seen))
+	}
+
+	var h Hasher[string] = HasherFunc[string](HashString)
+	if h.Hash("a") != HashString("a") {
+		t.Error("HasherFunc must call underlying function")
+	}
+}
+
+func TestHashCombine(t *testing.T) {
+	a, b := HashInt(1), HashInt(2)
+
+	for _, tt := range []struct {
+		name string
+		x, y uint64
+	}{
+		{"order matters", HashCombine(a, b), HashCombine(b, a)},
+		{"equal hashes don't cancel", HashCombine(a, a), HashCombine(b, b)},
+		{"zero hashes are counted", HashCombine(0), HashCombine(0, 0)},
+		{"empty differs from zero", HashCombine(), HashCombine(0)},
+		{"nesting is not flattening", HashCombine(HashCombine(a, b), a), HashCombine(a, b, a)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.x == tt.y {
+				t.Errorf("got same hashes %x", tt.x)
+			}
+		})
+	}
+
+	if HashCombine(a, b) != HashCombine(a, b) {
+		t.Error("HashCombine must be deterministic")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+// Clamp returns v limited to range [lo, hi]. It panics if lo > hi.
+func Clamp[T Ordered](v, lo, hi T) T { return ClampFunc(v, lo, hi, Compare[T]) }
+
+// ClampFunc is like Clamp, but uses cmp function to compare values.
+func ClampFunc[T any](v, lo, hi T, cmp func(a, b T) int) T {
+	switch {
+	case cmp(lo, hi) > 0:
+		panic("cmp.ClampFunc: lo > hi")
+	case cmp(v, lo) < 0:
+		return lo
+	case cmp(v, hi) > 0:
+		return hi
+	default:
+		return v
+	}
+}
+
+// MinOf returns the smallest of vals. Unlike builtin min, it accepts slices
+// (MinOf(s...)), and handles NaN same as Compare. It panics if vals is empty.
+func MinOf[T Ordered](vals ...T) T { return MinOfFunc(Compare[T], vals...) }
+
+// MaxOf returns the largest of vals. Unlike builtin max, it accepts slices
+// (MaxOf(s...)), and handles NaN same as Compare. It panics if vals is empty.
+func MaxOf[T Ordered](vals ...T) T { return MaxOfFunc(Compare[T], vals...) }
+
+// MinOfFunc is like MinOf, but uses cmp function to compare values. If there
+// are several minimal values, the first one is returned.
+func MinOfFunc[T any](cmp func(a, b T) int, vals ...T) T {
+	if len(vals) == 0 {
+		panic("cmp.MinOfFunc: empty list")
+	}
+
+	res := vals[0]
+	for _, v := range vals[1:] {
+		if cmp(v, res) < 0 {
+			res = v
+		}
+	}
+
+	return res
+}
+
+// MaxOfFunc is like MaxOf, but uses cmp function to compare values. If there
+// are several maximal values, the first one is returned.
+func MaxOfFunc[T any](cmp func(a, b T) int, vals ...T) T {
+	if len(vals) == 0 {
+		panic("cmp.MaxOfFunc: empty list")
+	}
+
+	res := vals[0]
+	for _, v := range vals[1:] {
+		if cmp(v, res) > 0 {
+			res = v
+		}
+	}
+
+	return res
+}
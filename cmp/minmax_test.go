@@ -0,0 +1,91 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+func TestClamp(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		v, lo, hi   float64
+		want        float64
+		shouldPanic bool
+	}{
+		{"in range", 5, 0, 10, 5, false},
+		{"below", -1, 0, 10, 0, false},
+		{"above", 11, 0, 10, 10, false},
+		{"on bounds", 10, 0, 10, 10, false},
+		{"empty range", 3, 5, 5, 5, false},
+		{"NaN", math.NaN(), 0, 10, 0, false}, // NaN is less than any number
+		{"lo > hi", 5, 10, 0, 0, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.shouldPanic {
+					t.Errorf("Clamp(%v, %v, %v): unexpected panic %v", tt.v, tt.lo, tt.hi, r)
+				}
+			}()
+			if got := Clamp(tt.v, tt.lo, tt.hi); got != tt.want {
+				t.Errorf("Clamp(%v, %v, %v) = %v, want %v", tt.v, tt.lo, tt.hi, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMinMaxOf(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		vals     []float64
+		min, max float64
+	}{
+		{"single", []float64{1}, 1, 1},
+		{"many", []float64{3, -1, 4, 1, 5}, -1, 5},
+		{"infinities", []float64{0, math.Inf(1), math.Inf(-1)}, math.Inf(-1), math.Inf(1)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MinOf(tt.vals...); got != tt.min {
+				t.Errorf("MinOf(%v) = %v, want %v", tt.vals, got, tt.min)
+			}
+			if got := MaxOf(tt.vals...); got != tt.max {
+				t.Errorf("MaxOf(%v) = %v, want %v", tt.vals, got, tt.max)
+			}
+		})
+	}
+
+	if got := MinOf(1, math.NaN(), -1); !math.IsNaN(got) {
+		t.Errorf("MinOf with NaN = %v, want NaN", got)
+	}
+	if got := MaxOf(1, math.NaN(), -1); got != 1 {
+		t.Errorf("MaxOf with NaN = %v, want 1", got)
+	}
+
+	// first of equal values is returned
+	type item struct{ key, seq int }
+	byKey := func(a, b item) int { return a.key - b.key }
+	vals := []item{{1, 0}, {0, 1}, {1, 2}, {0, 3}}
+	if got := MinOfFunc(byKey, vals...); got.seq != 1 {
+		t.Errorf("MinOfFunc(%v) = %v, want first minimal", vals, got)
+	}
+	if got := MaxOfFunc(byKey, vals...); got.seq != 0 {
+		t.Errorf("MaxOfFunc(%v) = %v, want first maximal", vals, got)
+	}
+
+	for name, f := range map[string]func(...int) int{"MinOf": MinOf[int], "MaxOf": MaxOf[int]} {
+		t.Run(name+" empty", func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v() must panic", name)
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Natural compares strings in human-friendly order: runs of digits are
+// compared as numbers, so "file2" < "file10". It's useful for sorting file
+// names for display.
+//
+// Numbers with leading zeros are equal to the same numbers without them, so
+// "01" and "1" are ordered by count of leading zeros, only if the rest of
+// strings is equal.
+func Natural(a, b string) int { return natural(a, b, false) }
+
+// NaturalFold is like Natural, but case-insensitive.
+func NaturalFold(a, b string) int { return natural(a, b, true) }
+
+func natural(a, b string, fold bool) int {
+	var tie int // result, if strings differ only in leading zeros
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			si, sj := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+
+			na, nb := strings.TrimLeft(a[si:i], "0"), strings.TrimLeft(b[sj:j], "0")
+			if c := Compare(len(na), len(nb)); c != 0 {
+				return c
+			} else if c := strings.Compare(na, nb); c != 0 {
+				return c
+			} else if tie == 0 {
+				tie = Compare(i-si, j-sj)
+			}
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a[i:])
+		rb, sb := utf8.DecodeRuneInString(b[j:])
+		if fold {
+			ra, rb = unicode.ToLower(ra), unicode.ToLower(rb)
+		}
+		if ra != rb {
+			return Compare(ra, rb)
+		}
+		i, j = i+sa, j+sb
+	}
+
+	if c := Compare(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+
+	return tie
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
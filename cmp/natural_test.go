@@ -0,0 +1,59 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"testing"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+func TestNatural(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"equal", "file1", "file1", 0},
+		{"empty", "", "a", -1},
+		{"digit runs as numbers", "file2", "file10", -1},
+		{"multiple digit runs", "v1.2.10", "v1.2.9", 1},
+		{"leading zeros are tie", "01", "1", 1},
+		{"leading zeros ignored if rest differs", "a01b", "a1c", -1},
+		{"leading zeros in long run", "0000000000000000000000001", "2", -1},
+		{"mixed alpha and digits", "abc", "ab1", 1},
+		{"digits after prefix", "x1y2", "x1y10", -1},
+		{"longer string", "file1", "file1a", -1},
+		{"overflowing digit runs", "a123456789012345678901234567890", "a99999999999999999999", 1},
+		{"equal overflowing digit runs", "a123456789012345678901234567890", "a123456789012345678901234567890", 0},
+		{"case sensitive", "B", "a", -1},
+		{"unicode", "файл2", "файл10", -1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Natural(tt.a, tt.b); got != tt.want {
+				t.Errorf("Natural(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+			if got := Natural(tt.b, tt.a); got != -tt.want {
+				t.Errorf("Natural(%q, %q) = %v, want %v", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestNaturalFold(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"File2", "file10", -1},
+		{"B", "a", 1},
+		{"ABC", "abc", 0},
+	} {
+		if got := NaturalFold(tt.a, tt.b); got != tt.want {
+			t.Errorf("NaturalFold(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
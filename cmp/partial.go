@@ -0,0 +1,54 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PartialCmp is implemented by partially ordered types: some pairs of values
+// (e.g. version constraints, or points in multidimensional space) can't be
+// ordered. ok is false for such pairs, and result is meaningless.
+type PartialCmp[T any] interface{ PartialCmp(T) (c int, ok bool) }
+
+// ErrIncomparable is returned, when values can't be ordered.
+var ErrIncomparable = errors.New("values are incomparable")
+
+// ComparePartial compares partially ordered values, returning
+// ErrIncomparable, if they can't be ordered.
+func ComparePartial[T PartialCmp[T]](a, b T) (int, error) {
+	if c, ok := a.PartialCmp(b); ok {
+		return c, nil
+	}
+
+	return 0, fmt.Errorf("%v and %v: %w", a, b, ErrIncomparable)
+}
+
+// MustComparePartial is like ComparePartial, but panics, if values can't be
+// ordered. It's a full comparator for cases, when incomparable values are
+// programmer's error.
+func MustComparePartial[T PartialCmp[T]](a, b T) int {
+	c, err := ComparePartial(a, b)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// ComparePartialFallback returns full comparator, which uses fallback for
+// values, that can't be ordered. fallback should be consistent with partial
+// order, otherwise sorting results are unpredictable.
+func ComparePartialFallback[T PartialCmp[T]](fallback func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		if c, ok := a.PartialCmp(b); ok {
+			return c
+		}
+
+		return fallback(a, b)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+// point is ordered by dominance: a <= b only if both coordinates are <=.
+type point struct{ x, y int }
+
+func (p point) PartialCmp(o point) (int, bool) {
+	switch {
+	case p == o:
+		return 0, true
+	case p.x <= o.x && p.y <= o.y:
+		return -1, true
+	case p.x >= o.x && p.y >= o.y:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
+func TestComparePartial(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b point
+		want int
+		err  error
+	}{
+		{"equal", point{1, 1}, point{1, 1}, 0, nil},
+		{"less", point{1, 1}, point{1, 2}, -1, nil},
+		{"greater", point{2, 2}, point{1, 2}, 1, nil},
+		{"incomparable", point{1, 2}, point{2, 1}, 0, ErrIncomparable},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComparePartial(tt.a, tt.b)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ComparePartial(%v, %v) error = %v, want %v", tt.a, tt.b, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("ComparePartial(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustComparePartial(t *testing.T) {
+	if got := MustComparePartial(point{1, 1}, point{2, 2}); got != -1 {
+		t.Errorf("MustComparePartial = %v, want -1", got)
+	}
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrIncomparable) {
+			t.Errorf("expected panic with ErrIncomparable, got %v", err)
+		}
+	}()
+	MustComparePartial(point{1, 2}, point{2, 1})
+}
+
+func TestComparePartialFallback(t *testing.T) {
+	var called bool
+	c := ComparePartialFallback(func(a, b point) int {
+		called = true
+		return Compare(a.x, b.x)
+	})
+
+	if got := c(point{1, 1}, point{2, 2}); got != -1 || called {
+		t.Errorf("comparable values: got %v, fallback called: %v", got, called)
+	}
+	if got := c(point{2, 1}, point{1, 2}); got != 1 || !called {
+		t.Errorf("incomparable values: got %v, fallback called: %v", got, called)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp
+
+import (
+	"time"
+)
+
+// Time compares time instants, returning -1 if a is before b, +1 if a is
+// after b, and 0 if they are the same instant, independently of their
+// locations.
+//
+// Same as Before and After, if both values have monotonic clock readings, they
+// are used for comparison, and wall clock readings are ignored. Strip them
+// with t.Round(0), if values from different processes are compared.
+func Time(a, b time.Time) int { return a.Compare(b) }
+
+// Duration compares durations.
+func Duration(a, b time.Duration) int { return Compare(a, b) }
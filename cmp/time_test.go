@@ -0,0 +1,57 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package cmp_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/quenbyako/ext/cmp"
+)
+
+func TestTime(t *testing.T) {
+	base := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("Tokyo", 9*60*60)
+
+	for _, tt := range []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		{"before", base, base.Add(time.Nanosecond), -1},
+		{"after", base.Add(time.Hour), base, 1},
+		{"equal", base, base, 0},
+		{"same instant in other location", base, base.In(tokyo), 0},
+		{"zero", time.Time{}, base, -1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Time(tt.a, tt.b); got != tt.want {
+				t.Errorf("Time(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+
+	// monotonic readings are ignored after stripping
+	now := time.Now()
+	if got := Time(now, now.Round(0)); got != 0 {
+		t.Errorf("Time(now, now.Round(0)) = %v, want 0", got)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	for _, tt := range []struct {
+		a, b time.Duration
+		want int
+	}{
+		{time.Second, time.Minute, -1},
+		{time.Minute, time.Second, 1},
+		{-time.Second, -time.Second, 0},
+	} {
+		if got := Duration(tt.a, tt.b); got != tt.want {
+			t.Errorf("Duration(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -40,3 +40,8 @@ type Complex interface {
 	~complex64 | ~complex128
 }
 
+// Number is a constraint that permits any real numeric type: integers and
+// floats.
+type Number interface {
+	Integer | Float
+}
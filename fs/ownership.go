@@ -144,6 +144,17 @@ func permString(m FileMode) (s string) {
 	return s
 }
 
+// Hints returns commands, which user can run to fix ownership issue. It's
+// compatible with hints of user package errors, so CLI tools can print them
+// uniformly.
+func (e ErrDifferentOwnership) Hints() []string {
+	if hint, ok := e.chmod(); ok {
+		return []string{hint}
+	}
+
+	return nil
+}
+
 func (e ErrDifferentOwnership) Error() string { return e.Unwrap().Error() }
 
 func (e ErrDifferentOwnership) Unwrap() error {
@@ -0,0 +1,200 @@
+package fuzz
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/quenbyako/ext/slices"
+)
+
+const defaultIterations = 100
+
+// Option configures [Check].
+type Option func(*checkOptions)
+
+type checkOptions struct {
+	iterations int
+	seed       *uint64
+}
+
+// Iterations sets number of property runs. Default is 100, with -short flag it
+// is reduced tenfold.
+func Iterations(n int) Option { return func(o *checkOptions) { o.iterations = n } }
+
+// WithSeed fixes seed of the run, overriding FUZZ_SEED environment variable.
+func WithSeed(n uint64) Option { return func(o *checkOptions) { o.seed = &n } }
+
+// T is passed to property in [Check]. Values are drawn with [Draw] and
+// [DrawShrink], failures are reported with Error, Fatal and similar methods,
+// same as in testing.T. Panics are considered failures too.
+type T struct {
+	seed   io.Reader
+	replay []any
+	draws  []draw
+	logs   []string
+	failed bool
+}
+
+type draw struct {
+	value  any
+	shrink Shrinker[any]
+}
+
+// failNow is a panic value, which stops the property in FailNow.
+type failNow struct{}
+
+// Draw generates value with f. Value is reported, if property fails, but it's
+// not shrunk, use [DrawShrink] for that.
+func Draw[V any](t *T, f Fuzzer[V]) V { return DrawShrink(t, f, nil) }
+
+// DrawShrink is like [Draw], but if property fails, value is minimized with s.
+func DrawShrink[V any](t *T, f Fuzzer[V], s Shrinker[V]) V {
+	var v V
+	var ok bool
+	if i := len(t.draws); i < len(t.replay) {
+		v, ok = t.replay[i].(V)
+	}
+	if !ok {
+		v = f(t.seed)
+	}
+
+	d := draw{value: v}
+	if s != nil {
+		d.shrink = func(a any) []any {
+			candidates := s(a.(V))
+			res := make([]any, len(candidates))
+			for i, c := range candidates {
+				res[i] = c
+			}
+			return res
+		}
+	}
+	t.draws = append(t.draws, d)
+
+	return v
+}
+
+func (t *T) Log(args ...any)                 { t.logs = append(t.logs, fmt.Sprintln(args...)) }
+func (t *T) Logf(format string, args ...any) { t.logs = append(t.logs, fmt.Sprintf(format, args...)) }
+func (t *T) Fail()                           { t.failed = true }
+func (t *T) Failed() bool                    { return t.failed }
+
+// FailNow marks property as failed and stops its execution. Like in testing.T,
+// it must be called from the goroutine, running the property.
+func (t *T) FailNow() {
+	t.Fail()
+	panic(failNow{})
+}
+
+func (t *T) Error(args ...any)                 { t.Log(args...); t.Fail() }
+func (t *T) Errorf(format string, args ...any) { t.Logf(format, args...); t.Fail() }
+func (t *T) Fatal(args ...any)                 { t.Log(args...); t.FailNow() }
+func (t *T) Fatalf(format string, args ...any) { t.Logf(format, args...); t.FailNow() }
+
+// Check runs property prop multiple times with values, drawn from fuzzers.
+// If property fails, drawn values are shrunk (if drawn with DrawShrink), and
+// test fails with minimal failing input and seed, which reproduces it:
+//
+//	fuzz.Check(t, func(t *fuzz.T) {
+//		s := fuzz.DrawShrink(t, fuzz.String(0, 100), fuzz.ShrinkString())
+//		if got := Reverse(Reverse(s)); got != s {
+//			t.Fatalf("got %q", got)
+//		}
+//	})
+//
+// Seed is random, unless it's set with FUZZ_SEED environment variable or
+// WithSeed option.
+func Check(t *testing.T, prop func(*T), opts ...Option) {
+	t.Helper()
+
+	o := checkOptions{iterations: defaultIterations}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if testing.Short() {
+		o.iterations = max(1, o.iterations/10)
+	}
+
+	seed, err := envSeed()
+	if err != nil {
+		t.Fatalf("invalid %v: %v", SeedEnv, err)
+	} else if o.seed != nil {
+		seed = *o.seed
+	}
+
+	for i := 0; i < o.iterations; i++ {
+		iterSeed := seed + uint64(i)
+		if res := runProp(prop, iterSeed, nil); res.failed {
+			res = shrinkProp(prop, iterSeed, res)
+			replay := fmt.Sprintf("replay with %v=%v", SeedEnv, seed)
+			if o.seed != nil {
+				replay = fmt.Sprintf("seed %v", seed)
+			}
+			t.Errorf("property failed on iteration %v (%v)\n%v", i+1, replay, res.report())
+			return
+		}
+	}
+}
+
+func runProp(prop func(*T), seed uint64, replay []any) (t *T) {
+	t = &T{seed: Seed(seed), replay: replay}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(failNow); !ok {
+				t.Logf("panic: %v", r)
+				t.Fail()
+			}
+		}
+	}()
+
+	prop(t)
+
+	return t
+}
+
+// shrinkProp minimizes drawn values one by one, replaying property with
+// other values fixed.
+func shrinkProp(prop func(*T), seed uint64, failed *T) *T {
+	values := failed.values()
+	for i, d := range failed.draws {
+		if d.shrink == nil {
+			continue
+		}
+
+		values[i] = Shrink(values[i], d.shrink, func(c any) bool {
+			replay := slices.Clone(values)
+			replay[i] = c
+			return runProp(prop, seed, replay).failed
+		})
+	}
+
+	if res := runProp(prop, seed, values); res.failed {
+		return res
+	}
+
+	return failed // flaky property, report original failure
+}
+
+func (t *T) values() []any {
+	res := make([]any, len(t.draws))
+	for i, d := range t.draws {
+		res[i] = d.value
+	}
+
+	return res
+}
+
+func (t *T) report() string {
+	var b strings.Builder
+	for i, d := range t.draws {
+		fmt.Fprintf(&b, "draw #%v: %#v\n", i, d.value)
+	}
+	for _, log := range t.logs {
+		b.WriteString(strings.TrimSuffix(log, "\n"))
+		b.WriteByte('\n')
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
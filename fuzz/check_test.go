@@ -0,0 +1,101 @@
+package fuzz
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	runs := 0
+	Check(t, func(t *T) {
+		runs++
+		if v := Draw(t, Int(0, 10)); v < 0 || v > 10 {
+			t.Fatalf("%v is out of range", v)
+		}
+	}, Iterations(20), WithSeed(1))
+
+	want := 20
+	if testing.Short() {
+		want = 2
+	}
+	if runs != want {
+		t.Errorf("property ran %v times, want %v", runs, want)
+	}
+}
+
+func TestRunProp_Deterministic(t *testing.T) {
+	prop := func(t *T) {
+		Draw(t, Int(0, 1000))
+		Draw(t, String(0, 10))
+	}
+
+	if a, b := runProp(prop, 42, nil).values(), runProp(prop, 42, nil).values(); !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed generated %v and %v", a, b)
+	}
+	if a, b := runProp(prop, 42, nil).values(), runProp(prop, 43, nil).values(); reflect.DeepEqual(a, b) {
+		t.Errorf("different seeds generated same values %v", a)
+	}
+}
+
+func TestShrinkProp(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		prop func(*T)
+		want []string
+	}{{
+		name: "shrink to minimal",
+		prop: func(t *T) {
+			a := DrawShrink(t, Int(1000, 1000000), ShrinkInt[int]())
+			b := DrawShrink(t, Int(1000, 1000000), ShrinkInt[int]())
+			if a > 100 && b > 10 {
+				t.Errorf("a=%v b=%v", a, b)
+			}
+		},
+		want: []string{"draw #0: 101", "draw #1: 11", "a=101 b=11"},
+	}, {
+		name: "not shrinkable",
+		prop: func(t *T) {
+			if v := Draw(t, Const(12345)); v > 0 {
+				t.Fatal("positive", v)
+			}
+		},
+		want: []string{"draw #0: 12345", "positive 12345"},
+	}, {
+		name: "panic",
+		prop: func(t *T) {
+			s := DrawShrink(t, Const("some string with x"), ShrinkString())
+			if strings.Contains(s, "x") {
+				panic("found x")
+			}
+		},
+		want: []string{`draw #0: "x"`, "panic: found x"},
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			res := runProp(tt.prop, 1, nil)
+			if !res.failed {
+				t.Fatal("property must fail")
+			}
+
+			report := shrinkProp(tt.prop, 1, res).report()
+			if got := strings.Split(report, "\n"); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got report:\n%v\nwant:\n%v", report, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestShrinkProp_Flaky(t *testing.T) {
+	calls := 0
+	prop := func(t *T) {
+		v := DrawShrink(t, Const(100), ShrinkInt[int]())
+		if calls++; calls == 1 {
+			t.Error("failed once with", v)
+		}
+	}
+
+	res := shrinkProp(prop, 1, runProp(prop, 1, nil))
+	if want := "draw #0: 100\nfailed once with 100"; res.report() != want {
+		t.Errorf("flaky property must report original failure, got:\n%v", res.report())
+	}
+}
@@ -0,0 +1,72 @@
+package fuzz
+
+import (
+	"fmt"
+	"io"
+)
+
+// Transform converts values of f with g. It's a Map combinator, named
+// differently, since Map generates maps.
+func Transform[A, B any](f Fuzzer[A], g func(A) B) Fuzzer[B] {
+	return func(seed io.Reader) B { return g(f(seed)) }
+}
+
+// FlatMap generates value with f, then generates final value with fuzzer,
+// returned by g. It's useful for dependent values, e.g. a length and a slice of
+// that length:
+//
+//	FlatMap(Int(1, 10), func(n int) Fuzzer[[]byte] {
+//		return Bytes(uint64(n), uint64(n))
+//	})
+func FlatMap[A, B any](f Fuzzer[A], g func(A) Fuzzer[B]) Fuzzer[B] {
+	return func(seed io.Reader) B { return g(f(seed))(seed) }
+}
+
+// Filter generates values with f until pred returns true. If none of
+// maxRetries attempts satisfy pred, Filter panics, since it usually means, that
+// predicate is too strict, and f must generate matching values directly.
+func Filter[T any](f Fuzzer[T], pred func(T) bool, maxRetries int) Fuzzer[T] {
+	if maxRetries <= 0 {
+		panic(fmt.Sprintf("maxRetries must be positive, got %v", maxRetries))
+	}
+
+	return func(seed io.Reader) T {
+		for i := 0; i < maxRetries; i++ {
+			if v := f(seed); pred(v) {
+				return v
+			}
+		}
+
+		panic(fmt.Sprintf("filter: no value satisfied predicate in %v attempts", maxRetries))
+	}
+}
+
+// Recursive generates tree-like values (expressions, nested configs, etc.)
+// with at most maxDepth levels of nesting. build returns fuzzer for the given
+// remaining depth, generating nested values with self:
+//
+//	type Expr struct { Op string; Args []*Expr }
+//
+//	fuzz.Recursive(func(self fuzz.Fuzzer[*Expr], depth int) fuzz.Fuzzer[*Expr] {
+//		if depth == 0 {
+//			return fuzz.Const(&Expr{Op: "x"})
+//		}
+//		return fuzz.Transform(fuzz.Slice(1, 3, self), func(args []*Expr) *Expr {
+//			return &Expr{Op: "+", Args: args}
+//		})
+//	}, 5)
+//
+// At depth 0 self returns zero value of T, so recursion always terminates.
+func Recursive[T any](build func(self Fuzzer[T], depth int) Fuzzer[T], maxDepth int) Fuzzer[T] {
+	if maxDepth < 0 {
+		panic(fmt.Sprintf("maxDepth must not be negative, got %v", maxDepth))
+	}
+
+	var zero T
+	f := build(Const(zero), 0)
+	for depth := 1; depth <= maxDepth; depth++ {
+		f = build(f, depth)
+	}
+
+	return f
+}
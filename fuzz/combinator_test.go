@@ -0,0 +1,116 @@
+package fuzz_test
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestTransform(t *testing.T) {
+	f, seed := Transform(Int(0, 99), strconv.Itoa), Seed(1)
+	for i := 0; i < 100; i++ {
+		s := f(seed)
+		if n, err := strconv.Atoi(s); err != nil || n < 0 || n > 99 {
+			t.Fatalf("unexpected value %q", s)
+		}
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	// slice length depends on generated value
+	f := FlatMap(Int(1, 10), func(n int) Fuzzer[[]int] {
+		return Slice(n, n+1, Const(n))
+	})
+
+	seed := Seed(1)
+	for i := 0; i < 100; i++ {
+		s := f(seed)
+		if len(s) == 0 || len(s) > 10 {
+			t.Fatalf("unexpected length %v", len(s))
+		}
+		for _, v := range s {
+			if v != len(s) {
+				t.Fatalf("%v: dependent value must be equal to length", s)
+			}
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+
+	f, seed := Filter(Int(0, 100), even, 100), Seed(1)
+	for i := 0; i < 100; i++ {
+		if v := f(seed); !even(v) {
+			t.Fatalf("%v doesn't satisfy predicate", v)
+		}
+	}
+
+	for name, f := range map[string]func(){
+		"no retries":       func() { Filter(Int(0, 100), even, 0) },
+		"strict predicate": func() { Filter(Const(1), even, 10)(Seed(1)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		})
+	}
+}
+
+func TestRecursive(t *testing.T) {
+	// each level either stops or goes one level deeper, so value is a depth
+	nested := func(maxDepth int) Fuzzer[int] {
+		return Recursive(func(self Fuzzer[int], depth int) Fuzzer[int] {
+			return func(seed io.Reader) int {
+				if Bool(0.3)(seed) {
+					return 0
+				}
+				return self(seed) + 1
+			}
+		}, maxDepth)
+	}
+
+	for _, maxDepth := range []int{0, 1, 5} {
+		f, seed := nested(maxDepth), Seed(1)
+		seen := make(map[int]bool)
+		for i := 0; i < 1000; i++ {
+			v := f(seed)
+			if v < 0 || v > maxDepth+1 {
+				t.Fatalf("maxDepth %v: %v is too deep", maxDepth, v)
+			}
+			seen[v] = true
+		}
+		if len(seen) != maxDepth+2 {
+			t.Errorf("maxDepth %v: not every depth is generated: %v", maxDepth, seen)
+		}
+	}
+
+	var depths []int
+	Recursive(func(self Fuzzer[int], depth int) Fuzzer[int] {
+		depths = append(depths, depth)
+		return self
+	}, 3)
+	if !reflect.DeepEqual(depths, []int{0, 1, 2, 3}) {
+		t.Errorf("unexpected build depths: %v", depths)
+	}
+
+	// innermost self is zero value
+	f := Recursive(func(self Fuzzer[string], depth int) Fuzzer[string] { return self }, 2)
+	if v := f(Seed(1)); v != "" {
+		t.Errorf("expected zero value, got %q", v)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on negative maxDepth")
+		}
+	}()
+	Recursive(func(self Fuzzer[int], depth int) Fuzzer[int] { return self }, -1)
+}
@@ -0,0 +1,147 @@
+package fuzz
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+const defaultMaxSteps = 50
+
+// Command is an operation of model-based test. Create it with [NewCommand].
+type Command[S any] struct {
+	Name string
+	// Pre reports whether command can be applied to state. If it's nil,
+	// command is always applicable.
+	Pre func(state S) bool
+
+	gen func(seed io.Reader) any
+	run func(t *T, state S, arg any)
+}
+
+// NewCommand creates a command, which generates argument with gen, then
+// applies it to state with run. run must apply command both to the system
+// under test and to the reference model (both are usually fields of S), then
+// check postconditions, reporting failures with t.
+func NewCommand[S, A any](name string, gen Fuzzer[A], run func(t *T, state S, arg A)) Command[S] {
+	return Command[S]{
+		Name: name,
+		gen:  func(seed io.Reader) any { return gen(seed) },
+		run:  func(t *T, state S, arg any) { run(t, state, arg.(A)) },
+	}
+}
+
+// Commands generates sequences of commands and runs them against fresh state,
+// created by Init:
+//
+//	type state struct {
+//		sys   set.Set[int]
+//		model map[int]bool
+//	}
+//
+//	fuzz.Commands[*state]{
+//		Init: func() *state { return &state{set.New[int](), map[int]bool{}} },
+//		Commands: []fuzz.Command[*state]{
+//			fuzz.NewCommand("Add", fuzz.Int(0, 10), func(t *fuzz.T, s *state, v int) {
+//				s.sys.Add(v)
+//				s.model[v] = true
+//			}),
+//			fuzz.NewCommand("Size", fuzz.Zero[struct{}](), func(t *fuzz.T, s *state, _ struct{}) {
+//				if s.sys.Size() != len(s.model) {
+//					t.Fatalf("size %v, want %v", s.sys.Size(), len(s.model))
+//				}
+//			}),
+//		},
+//	}.Check(t)
+//
+// Whole sequence is generated before execution, and commands, which
+// preconditions are not satisfied at the moment, are skipped. So, when
+// failing sequence is shrunk, its subsequences are still valid.
+type Commands[S any] struct {
+	Init     func() S
+	Commands []Command[S]
+	// MaxSteps limits length of sequence. Default is 50.
+	MaxSteps int
+}
+
+// step is a generated command with its argument.
+type step[S any] struct {
+	cmd *Command[S]
+	arg any
+}
+
+func (s step[S]) GoString() string { return fmt.Sprintf("%v(%#v)", s.cmd.Name, s.arg) }
+
+// sequence is a list of steps, printed in readable form in failure reports.
+type sequence[S any] []step[S]
+
+func (s sequence[S]) GoString() string {
+	steps := make([]string, len(s))
+	for i, step := range s {
+		steps[i] = step.GoString()
+	}
+
+	return "[" + strings.Join(steps, ", ") + "]"
+}
+
+func shrinkSequence[S any](s sequence[S]) []sequence[S] {
+	candidates := ShrinkSlice[step[S]](nil)(s)
+	res := make([]sequence[S], len(candidates))
+	for i, c := range candidates {
+		res[i] = c
+	}
+
+	return res
+}
+
+// Check runs command sequences as property with [Check].
+func (c Commands[S]) Check(t *testing.T, opts ...Option) {
+	t.Helper()
+	Check(t, c.Run, opts...)
+}
+
+// Run draws sequence of commands from t and applies it to fresh state. Failed
+// sequence is shrunk by removing steps.
+func (c Commands[S]) Run(t *T) {
+	if c.Init == nil {
+		panic("no init function")
+	} else if len(c.Commands) == 0 {
+		panic("no commands")
+	}
+
+	maxSteps := c.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = defaultMaxSteps
+	}
+
+	steps := DrawShrink(t, Transform(Slice(1, maxSteps+1, c.step), func(s []step[S]) sequence[S] {
+		return s
+	}), shrinkSequence[S])
+
+	var current step[S]
+	completed := false
+	defer func() {
+		if !completed || t.Failed() { // panicked or failed
+			t.Logf("failed on %#v", current)
+		}
+	}()
+
+	state := c.Init()
+	for _, current = range steps {
+		if current.cmd.Pre != nil && !current.cmd.Pre(state) {
+			continue
+		}
+
+		if current.cmd.run(t, state, current.arg); t.Failed() {
+			return
+		}
+	}
+	completed = true
+}
+
+func (c Commands[S]) step(seed io.Reader) step[S] {
+	cmd := &c.Commands[uint64n(seed, uint64(len(c.Commands)-1))]
+
+	return step[S]{cmd: cmd, arg: cmd.gen(seed)}
+}
@@ -0,0 +1,70 @@
+package fuzz
+
+import (
+	"strings"
+	"testing"
+)
+
+// buggySet counts duplicates in its size.
+type buggySet struct {
+	items map[int]bool
+	size  int
+}
+
+type setState struct {
+	sys   *buggySet
+	model map[int]bool
+}
+
+func setCommands() Commands[*setState] {
+	return Commands[*setState]{
+		Init: func() *setState { return &setState{&buggySet{items: map[int]bool{}}, map[int]bool{}} },
+		Commands: []Command[*setState]{
+			NewCommand("Add", Const(1), func(t *T, s *setState, v int) {
+				s.sys.items[v] = true
+				s.sys.size++
+				s.model[v] = true
+			}),
+			NewCommand("Size", Const(0), func(t *T, s *setState, _ int) {
+				if s.sys.size != len(s.model) {
+					t.Fatalf("size %v, want %v", s.sys.size, len(s.model))
+				}
+			}),
+		},
+	}
+}
+
+func TestCommands_Shrink(t *testing.T) {
+	c := setCommands()
+
+	var res *T
+	for seed := uint64(0); res == nil || !res.failed; seed++ {
+		if res = runProp(c.Run, seed, nil); res.failed {
+			res = shrinkProp(c.Run, seed, res)
+		}
+	}
+
+	want := "draw #0: [Add(1), Add(1), Size(0)]"
+	if got, _, _ := strings.Cut(res.report(), "\n"); got != want {
+		t.Errorf("sequence is not minimal, got report:\n%v", res.report())
+	}
+	if !strings.Contains(res.report(), "size 2, want 1") {
+		t.Errorf("failure message is missing, got report:\n%v", res.report())
+	}
+}
+
+func TestCommands_Invalid(t *testing.T) {
+	for name, c := range map[string]Commands[*setState]{
+		"no init":     {Commands: setCommands().Commands},
+		"no commands": {Init: setCommands().Init},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			c.Run(&T{seed: Seed(1)})
+		})
+	}
+}
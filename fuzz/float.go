@@ -0,0 +1,85 @@
+package fuzz
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// FloatOption configures float fuzzers.
+type FloatOption func(*floatOptions)
+
+type floatOptions struct {
+	nan, inf, subnormal float64
+}
+
+// WithNaN makes fuzzer return NaN with given chance (0 to 1).
+func WithNaN(chance float64) FloatOption { return func(o *floatOptions) { o.nan = chance } }
+
+// WithInf makes fuzzer return +Inf or -Inf with given chance (0 to 1). Infinite
+// values are returned regardless of the range.
+func WithInf(chance float64) FloatOption { return func(o *floatOptions) { o.inf = chance } }
+
+// WithSubnormals makes fuzzer return subnormal numbers (the smallest ones,
+// which lose precision) with given chance (0 to 1), if they fit into the
+// range.
+func WithSubnormals(chance float64) FloatOption {
+	return func(o *floatOptions) { o.subnormal = chance }
+}
+
+// Float64Range generates numbers in range [min, max], which can be negative
+// and as wide as [-MaxFloat64, MaxFloat64]. Special values (NaN, ±Inf and
+// subnormals) are generated only if requested by options.
+func Float64Range(min, max float64, opts ...FloatOption) Fuzzer[float64] {
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		panic(fmt.Sprintf("bounds must be finite: %v, %v", min, max))
+	} else if min > max {
+		panic(fmt.Sprintf("min > max: %v > %v", min, max))
+	}
+
+	var o floatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(seed io.Reader) float64 {
+		switch r := unitFloat64(seed); {
+		case r < o.nan:
+			return math.NaN()
+		case r < o.nan+o.inf:
+			return math.Inf(1 - 2*int(uint64n(seed, 1)))
+		case r < o.nan+o.inf+o.subnormal:
+			if v, ok := subnormal(seed, min, max); ok {
+				return v
+			}
+		}
+
+		// interpolation doesn't overflow, even when max-min does
+		u := unitFloat64(seed)
+		return math.Min(max, math.Max(min, min*(1-u)+max*u))
+	}
+}
+
+// unitFloat64 returns uniformly distributed number in range [0, 1).
+func unitFloat64(seed io.Reader) float64 {
+	return float64(readUint64(seed)>>11) / (1 << 53)
+}
+
+// subnormal returns random subnormal number in range [min, max], if it's
+// possible.
+func subnormal(seed io.Reader, min, max float64) (float64, bool) {
+	v := math.Float64frombits(1 + uint64n(seed, 1<<52-2)) // positive subnormal
+	switch {
+	case v <= max && -v >= min:
+		if uint64n(seed, 1) == 0 {
+			v = -v
+		}
+		return v, true
+	case v >= min && v <= max:
+		return v, true
+	case -v >= min && -v <= max:
+		return -v, true
+	default:
+		return 0, false
+	}
+}
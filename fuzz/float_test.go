@@ -0,0 +1,84 @@
+package fuzz_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestFloat64Range(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		min, max float64
+	}{
+		{"single value", 1.5, 1.5},
+		{"unit", 0, 1},
+		{"negative", -10, -5},
+		{"full range", -math.MaxFloat64, math.MaxFloat64},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := Float64Range(tt.min, tt.max), Seed(1)
+			for i := 0; i < 1000; i++ {
+				if v := f(seed); !(v >= tt.min && v <= tt.max) {
+					t.Fatalf("%v is out of range [%v, %v]", v, tt.min, tt.max)
+				}
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		name     string
+		min, max float64
+	}{
+		{"min > max", 1, 0},
+		{"NaN", math.NaN(), 1},
+		{"Inf", 0, math.Inf(1)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			Float64Range(tt.min, tt.max)
+		})
+	}
+}
+
+func TestFloat64Range_Special(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		min, max float64
+		opt      FloatOption
+		check    func(float64) bool
+	}{
+		{"NaN", 0, 1, WithNaN(1), math.IsNaN},
+		{"Inf", 0, 1, WithInf(1), func(v float64) bool { return math.IsInf(v, 0) }},
+		{"subnormals", -1, 1, WithSubnormals(1), isSubnormal},
+		{"positive subnormals", 0, 1, WithSubnormals(1), func(v float64) bool { return isSubnormal(v) && v > 0 }},
+		{"subnormals out of range", 1, 2, WithSubnormals(1), func(v float64) bool { return v >= 1 && v <= 2 }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := Float64Range(tt.min, tt.max, tt.opt), Seed(1)
+			for i := 0; i < 1000; i++ {
+				if v := f(seed); !tt.check(v) {
+					t.Fatalf("unexpected value %v", v)
+				}
+			}
+		})
+	}
+
+	t.Run("both signs of Inf", func(t *testing.T) {
+		f, seed := Float64Range(0, 1, WithInf(1)), Seed(1)
+		seen := map[float64]bool{}
+		for i := 0; i < 100; i++ {
+			seen[f(seed)] = true
+		}
+		if !seen[math.Inf(1)] || !seen[math.Inf(-1)] {
+			t.Errorf("got %v, want both infinities", seen)
+		}
+	})
+}
+
+func isSubnormal(v float64) bool { return v != 0 && math.Abs(v) < 0x1p-1022 }
@@ -0,0 +1,190 @@
+package fuzz
+
+import (
+	"encoding/hex"
+	"io"
+	"net/netip"
+	"strings"
+)
+
+// UUIDv4 generates random (version 4) UUIDs in canonical form, e.g.
+// "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+func UUIDv4() Fuzzer[string] {
+	return func(seed io.Reader) string {
+		var u [16]byte
+		readFull(seed, u[:])
+		return formatUUID(u, 4)
+	}
+}
+
+// UUIDv7 generates time-ordered (version 7) UUIDs in canonical form.
+// Timestamp is random too, so generated UUIDs are not sorted.
+func UUIDv7() Fuzzer[string] {
+	return func(seed io.Reader) string {
+		var u [16]byte
+		readFull(seed, u[:])
+		return formatUUID(u, 7)
+	}
+}
+
+func formatUUID(u [16]byte, version byte) string {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	return string(buf[:])
+}
+
+const (
+	alnum     = "abcdefghijklmnopqrstuvwxyz0123456789"
+	emailText = alnum + "ABCDEFGHIJKLMNOPQRSTUVWXYZ!#$%&'*+-/=?^_`{|}~"
+)
+
+// Email generates plausible addresses per RFC 5322 dot-atom syntax: local
+// part consists of atoms, separated by single dots, domain consists of 1-3
+// labels and top-level domain.
+func Email() Fuzzer[string] {
+	return func(seed io.Reader) string {
+		var b strings.Builder
+		for i, n := 0, 1+int(uint64n(seed, 2)); i < n; i++ {
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(randomText(seed, emailText, 1, 10))
+		}
+		b.WriteByte('@')
+		b.WriteString(hostname(seed))
+
+		return b.String()
+	}
+}
+
+// URLOption configures URL fuzzer.
+type URLOption func(*urlOptions)
+
+type urlOptions struct {
+	schemes []string
+	hosts   []string
+}
+
+// WithSchemes sets schemes of generated URLs. Default is http and https,
+// empty list keeps it.
+func WithSchemes(schemes ...string) URLOption {
+	return func(o *urlOptions) { o.schemes = orDefault(schemes, o.schemes) }
+}
+
+// WithHosts sets hosts (with optional port) of generated URLs. By default
+// hosts are random domain names.
+func WithHosts(hosts ...string) URLOption { return func(o *urlOptions) { o.hosts = hosts } }
+
+// URL generates absolute URLs with random path, and optionally query and
+// fragment. Path segments and query values contain only unreserved characters,
+// so URLs are valid without escaping.
+func URL(opts ...URLOption) Fuzzer[string] {
+	o := urlOptions{schemes: []string{"http", "https"}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(seed io.Reader) string {
+		var b strings.Builder
+		b.WriteString(pick(seed, o.schemes))
+		b.WriteString("://")
+		if len(o.hosts) > 0 {
+			b.WriteString(pick(seed, o.hosts))
+		} else {
+			b.WriteString(hostname(seed))
+		}
+
+		for i, n := 0, int(uint64n(seed, 3)); i < n; i++ {
+			b.WriteByte('/')
+			b.WriteString(randomText(seed, alnum+"-._~", 1, 12))
+		}
+		if uint64n(seed, 1) == 0 {
+			for i, n := 0, 1+int(uint64n(seed, 2)); i < n; i++ {
+				b.WriteByte("?&"[min(i, 1)])
+				b.WriteString(randomText(seed, alnum, 1, 8))
+				b.WriteByte('=')
+				b.WriteString(randomText(seed, alnum+"-._~", 0, 12))
+			}
+		}
+		if uint64n(seed, 3) == 0 {
+			b.WriteByte('#')
+			b.WriteString(randomText(seed, alnum, 1, 8))
+		}
+
+		return b.String()
+	}
+}
+
+// hostname generates domain name with 1-3 labels and top-level domain.
+func hostname(seed io.Reader) string {
+	var b strings.Builder
+	for i, n := 0, 1+int(uint64n(seed, 2)); i < n; i++ {
+		b.WriteString(randomText(seed, alnum, 1, 12))
+		b.WriteByte('.')
+	}
+	b.WriteString(pick(seed, []string{"com", "org", "net", "io", "dev", "example"}))
+
+	return b.String()
+}
+
+// IPv4 generates any IPv4 address, including special ones (loopback,
+// multicast, etc.).
+func IPv4() Fuzzer[netip.Addr] {
+	return func(seed io.Reader) netip.Addr {
+		var a [4]byte
+		readFull(seed, a[:])
+		return netip.AddrFrom4(a)
+	}
+}
+
+// IPv6 generates any IPv6 address, without zone.
+func IPv6() Fuzzer[netip.Addr] {
+	return func(seed io.Reader) netip.Addr {
+		var a [16]byte
+		readFull(seed, a[:])
+		return netip.AddrFrom16(a)
+	}
+}
+
+// Addr generates IPv4 or IPv6 address with equal chance.
+func Addr() Fuzzer[netip.Addr] {
+	v4, v6 := IPv4(), IPv6()
+	return func(seed io.Reader) netip.Addr {
+		if uint64n(seed, 1) == 0 {
+			return v4(seed)
+		}
+		return v6(seed)
+	}
+}
+
+// randomText generates string in range [min, max] of chars from alphabet.
+func randomText(seed io.Reader, alphabet string, min, max int) string {
+	buf := make([]byte, min+int(uint64n(seed, uint64(max-min))))
+	for i := range buf {
+		buf[i] = alphabet[uint64n(seed, uint64(len(alphabet)-1))]
+	}
+
+	return string(buf)
+}
+
+func pick[T any](seed io.Reader, items []T) T {
+	return items[uint64n(seed, uint64(len(items)-1))]
+}
+
+func readFull(seed io.Reader, buf []byte) {
+	if _, err := io.ReadFull(seed, buf); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,111 @@
+package fuzz_test
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+	"github.com/quenbyako/ext/slices"
+)
+
+func TestUUID(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		f    Fuzzer[string]
+		re   *regexp.Regexp
+	}{
+		{"v4", UUIDv4(), regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{"v7", UUIDv7(), regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			seed, seen := Seed(1), map[string]bool{}
+			for i := 0; i < 1000; i++ {
+				u := tt.f(seed)
+				if !tt.re.MatchString(u) {
+					t.Fatalf("%q has invalid format, version or variant", u)
+				}
+				seen[u] = true
+			}
+			if len(seen) != 1000 {
+				t.Errorf("got %v duplicates", 1000-len(seen))
+			}
+		})
+	}
+}
+
+func TestEmail(t *testing.T) {
+	f, seed := Email(), Seed(1)
+	for i := 0; i < 1000; i++ {
+		s := f(seed)
+		addr, err := mail.ParseAddress(s)
+		if err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+		if addr.Address != s {
+			t.Fatalf("%q parsed as %q", s, addr.Address)
+		}
+	}
+}
+
+func TestURL(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		opts    []URLOption
+		schemes []string
+		hosts   []string
+	}{
+		{"default", nil, []string{"http", "https"}, nil},
+		{"empty schemes", []URLOption{WithSchemes()}, []string{"http", "https"}, nil},
+		{"custom", []URLOption{WithSchemes("ftp"), WithHosts("localhost:8080", "example.com")}, []string{"ftp"}, []string{"localhost:8080", "example.com"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := URL(tt.opts...), Seed(1)
+			for i := 0; i < 1000; i++ {
+				s := f(seed)
+				u, err := url.Parse(s)
+				if err != nil {
+					t.Fatalf("%q: %v", s, err)
+				}
+				if !u.IsAbs() || u.Host == "" || u.String() != s {
+					t.Fatalf("%q is not a valid absolute URL without escaping: %#v", s, u)
+				}
+				if !slices.Contains(tt.schemes, u.Scheme) {
+					t.Fatalf("%q: unexpected scheme %q", s, u.Scheme)
+				}
+				if tt.hosts != nil && !slices.Contains(tt.hosts, u.Host) {
+					t.Fatalf("%q: unexpected host %q", s, u.Host)
+				}
+			}
+		})
+	}
+}
+
+func TestAddr(t *testing.T) {
+	seed := Seed(1)
+	for i := 0; i < 1000; i++ {
+		if a := IPv4()(seed); !a.Is4() {
+			t.Fatalf("IPv4: got %v", a)
+		}
+		if a := IPv6()(seed); !a.Is6() || a.Zone() != "" {
+			t.Fatalf("IPv6: got %v", a)
+		}
+	}
+
+	var v4, v6 int
+	f := Addr()
+	for i := 0; i < 1000; i++ {
+		switch a := f(seed); {
+		case a.Is4():
+			v4++
+		case a.Is6():
+			v6++
+		default:
+			t.Fatalf("invalid address %v", a)
+		}
+	}
+	if v4 < 400 || v6 < 400 {
+		t.Errorf("Addr: got %v IPv4 and %v IPv6 addresses, want equal chance", v4, v6)
+	}
+}
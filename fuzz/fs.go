@@ -0,0 +1,115 @@
+package fuzz
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"testing/fstest"
+)
+
+// FSOption configures [FS] fuzzer.
+type FSOption func(*fsOptions)
+
+type fsOptions struct {
+	maxDepth     int
+	maxEntries   int
+	minFileSize  uint64
+	maxFileSize  uint64
+	fileModes    []fs.FileMode
+	dirModes     []fs.FileMode
+	uids, gids   []uint32
+	withoutOwner bool
+}
+
+// WithMaxDepth sets maximum nesting of directories. Default is 3.
+func WithMaxDepth(n int) FSOption { return func(o *fsOptions) { o.maxDepth = n } }
+
+// WithMaxEntries sets maximum number of entries in each directory. Default is
+// 5.
+func WithMaxEntries(n int) FSOption { return func(o *fsOptions) { o.maxEntries = n } }
+
+// WithFileSize sets range of file sizes [min, max). Default is [0, 1024).
+func WithFileSize(min, max uint64) FSOption {
+	return func(o *fsOptions) { o.minFileSize, o.maxFileSize = min, max }
+}
+
+// WithFileModes sets permissions, which are picked for files. By default,
+// common permissions are used, including unreadable and unwritable ones. Empty
+// list keeps defaults.
+func WithFileModes(modes ...fs.FileMode) FSOption {
+	return func(o *fsOptions) { o.fileModes = orDefault(modes, o.fileModes) }
+}
+
+// WithDirModes sets permissions, which are picked for directories. By default,
+// common permissions are used, including unreadable and unwritable ones. Empty
+// list keeps defaults.
+func WithDirModes(modes ...fs.FileMode) FSOption {
+	return func(o *fsOptions) { o.dirModes = orDefault(modes, o.dirModes) }
+}
+
+// WithOwners sets user and group ids, which are picked as owners of entries.
+// Default is 0 (root) and 1000 for both, empty list keeps it. Ownership is
+// supported only on unix.
+func WithOwners(uids, gids []uint32) FSOption {
+	return func(o *fsOptions) { o.uids, o.gids = orDefault(uids, o.uids), orDefault(gids, o.gids) }
+}
+
+// WithoutOwners disables ownership info in generated entries, so they are
+// treated as owned by root.
+func WithoutOwners() FSOption { return func(o *fsOptions) { o.withoutOwner = true } }
+
+// FS generates random directory trees. Each entry has random permissions, and,
+// on unix, unless WithoutOwners is set, ownership, stored as *syscall.Stat_t in
+// Sys field, same as in os.DirFS, so ownership-aware code (e.g.
+// [github.com/quenbyako/ext/fs.CheckFileAbleToWrite]) could be tested with
+// realistic trees.
+func FS(opts ...FSOption) Fuzzer[fstest.MapFS] {
+	o := fsOptions{
+		maxDepth:    3,
+		maxEntries:  5,
+		maxFileSize: 1024,
+		fileModes:   []fs.FileMode{0o644, 0o600, 0o755, 0o444, 0o222, 0o000},
+		dirModes:    []fs.FileMode{0o755, 0o700, 0o555, 0o333, 0o111, 0o000},
+		uids:        []uint32{0, 1000},
+		gids:        []uint32{0, 1000},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	data := Bytes(o.minFileSize, o.maxFileSize)
+
+	return func(seed io.Reader) fstest.MapFS {
+		m := make(fstest.MapFS)
+		o.fillDir(seed, m, data, ".", 0)
+
+		return m
+	}
+}
+
+func (o fsOptions) fillDir(seed io.Reader, m fstest.MapFS, data Fuzzer[[]byte], dir string, depth int) {
+	used := make(map[string]bool)
+	for i, n := 0, int(uint64n(seed, uint64(o.maxEntries))); i < n; i++ {
+		name := randomText(seed, alnum, 1, 1) + randomText(seed, alnum+"-_.", 0, 11)
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		p := path.Join(dir, name)
+
+		if depth < o.maxDepth && uint64n(seed, 2) == 0 {
+			m[p] = &fstest.MapFile{Mode: fs.ModeDir | pick(seed, o.dirModes), Sys: o.owner(seed)}
+			o.fillDir(seed, m, data, p, depth+1)
+			continue
+		}
+
+		m[p] = &fstest.MapFile{Data: data(seed), Mode: pick(seed, o.fileModes), Sys: o.owner(seed)}
+	}
+}
+
+func orDefault[T any](values, def []T) []T {
+	if len(values) == 0 {
+		return def
+	}
+	return values
+}
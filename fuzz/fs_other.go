@@ -0,0 +1,8 @@
+//go:build !unix
+
+package fuzz
+
+import "io"
+
+// owner returns nothing, since there is no portable way to store ownership.
+func (o fsOptions) owner(io.Reader) any { return nil }
@@ -0,0 +1,45 @@
+package fuzz_test
+
+import (
+	"io/fs"
+	"path"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestFS(t *testing.T) {
+	fsys := FS(WithMaxDepth(2), WithFileModes(), WithDirModes(), WithOwners(nil, nil))(Seed(1))
+
+	for name, f := range fsys {
+		if !fs.ValidPath(name) {
+			t.Errorf("invalid path %q", name)
+		}
+		if dir := path.Dir(name); dir != "." {
+			if parent, ok := fsys[dir]; !ok || !parent.Mode.IsDir() {
+				t.Errorf("%v: parent directory is missing", name)
+			}
+		}
+		if f.Mode.IsDir() && len(f.Data) > 0 {
+			t.Errorf("%v: directory must not have data", name)
+		}
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if depth := len(splitPath(name)); depth > 3 {
+			t.Errorf("%v: too deep", name)
+		}
+		return err
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func splitPath(name string) []string {
+	if name == "." {
+		return nil
+	}
+	dir, file := path.Split(name)
+	return append(splitPath(path.Clean(dir)), file)
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package fuzz
+
+import (
+	"io"
+	"syscall"
+)
+
+func (o fsOptions) owner(seed io.Reader) any {
+	if o.withoutOwner {
+		return nil
+	}
+
+	return &syscall.Stat_t{Uid: pick(seed, o.uids), Gid: pick(seed, o.gids)}
+}
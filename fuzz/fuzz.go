@@ -52,11 +52,19 @@ func Uint64(min, max uint64) Fuzzer[uint64] {
 	}
 }
 
-func Ptr[T any](chance float64, f Fuzzer[T]) Fuzzer[*T] {
+func Ptr[T any](chance float64, f Fuzzer[T], opts ...PtrOption) Fuzzer[*T] {
+	var o ptrOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return func(seed io.Reader) *T {
 		if Bool(chance)(seed) {
 			return nil
 		}
+		if o.zeroChance > 0 && Bool(o.zeroChance)(seed) {
+			return new(T)
+		}
 
 		return ptr(f(seed))
 	}
@@ -101,11 +109,13 @@ func Bytes(min, max uint64) Fuzzer[[]byte] {
 	}
 }
 
-func String(min, max uint64) Fuzzer[string] {
+func String(min, max uint64, opts ...SizeOption) Fuzzer[string] {
+	o := newSizeOptions(opts)
+
 	return func(seed io.Reader) string {
 		const letters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
 		const len = len(letters)
-		resultLen := Uint64(min, max)(seed)
+		resultLen := o.length(seed, min, max)
 
 		return string(slices.Generate(int(resultLen), func(int) byte {
 			num, _ := rand.Int(seed, big.NewInt(int64(len)))
@@ -114,9 +124,11 @@ func String(min, max uint64) Fuzzer[string] {
 	}
 }
 
-func Slice[T any](min, max int, f Fuzzer[T]) Fuzzer[[]T] {
+func Slice[T any](min, max int, f Fuzzer[T], opts ...SizeOption) Fuzzer[[]T] {
+	o := newSizeOptions(opts)
+
 	return func(seed io.Reader) []T {
-		l := Uint64(uint64(min), uint64(max))(seed)
+		l := o.length(seed, uint64(min), uint64(max))
 		s := make([]T, l)
 		for i := range s {
 			s[i] = f(seed)
@@ -126,9 +138,11 @@ func Slice[T any](min, max int, f Fuzzer[T]) Fuzzer[[]T] {
 	}
 }
 
-func Map[K comparable, V any](min, max int, k Fuzzer[K], v Fuzzer[V]) Fuzzer[map[K]V] {
+func Map[K comparable, V any](min, max int, k Fuzzer[K], v Fuzzer[V], opts ...SizeOption) Fuzzer[map[K]V] {
+	o := newSizeOptions(opts)
+
 	return func(seed io.Reader) map[K]V {
-		l := Uint64(uint64(min), uint64(max))(seed)
+		l := o.length(seed, uint64(min), uint64(max))
 		m := make(map[K]V, l)
 		for i := min; i < min+int(l); i++ {
 			m[k(seed)] = v(seed)
@@ -0,0 +1,35 @@
+package fuzz
+
+import (
+	"bytes"
+	"hash/fnv"
+	"io"
+	"testing"
+)
+
+// ToGoFuzz adapts f to native Go fuzzing: corpus entries are used as seed of
+// f (see [FromBytes]), so coverage-guided mutations of corpus are mutations of
+// generated values:
+//
+//	func FuzzParse(f *testing.F) {
+//		fuzz.ToGoFuzz(fuzz.String(0, 64))(f, func(t *testing.T, s string) {
+//			Parse(s)
+//		})
+//	}
+func ToGoFuzz[T any](f Fuzzer[T]) func(*testing.F, func(*testing.T, T)) {
+	return func(tf *testing.F, fn func(*testing.T, T)) {
+		tf.Helper()
+		tf.Fuzz(func(t *testing.T, data []byte) { fn(t, f(FromBytes(data))) })
+	}
+}
+
+// FromBytes returns seed, which reads data first, then continues with
+// deterministic stream, seeded by data hash. Fuzzers never run out of seed, so
+// any byte slice (e.g. go fuzz corpus entry) can be used to generate values,
+// and same data always produces same value.
+func FromBytes(data []byte) io.Reader {
+	h := fnv.New64a()
+	h.Write(data)
+
+	return io.MultiReader(bytes.NewReader(data), Seed(h.Sum64()))
+}
@@ -0,0 +1,52 @@
+package fuzz_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestFromBytes(t *testing.T) {
+	read := func(r io.Reader, n int) []byte {
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	for _, data := range [][]byte{nil, {}, {1}, []byte("some corpus entry")} {
+		// data is read first, then stream continues
+		got := read(FromBytes(data), len(data)+100)
+		if !bytes.Equal(got[:len(data)], data) {
+			t.Errorf("FromBytes(%q): stream must start with data, got %q", data, got)
+		}
+		if again := read(FromBytes(data), len(data)+100); !bytes.Equal(got, again) {
+			t.Errorf("FromBytes(%q) must be deterministic", data)
+		}
+	}
+
+	// tails of different data are different too
+	if a, b := read(FromBytes([]byte{1}), 64), read(FromBytes([]byte{2}), 64); bytes.Equal(a[1:], b[1:]) {
+		t.Error("FromBytes: different data must produce different streams")
+	}
+}
+
+func FuzzToGoFuzz(f *testing.F) {
+	f.Add([]byte(nil))
+	f.Add([]byte("seed"))
+	f.Add(bytes.Repeat([]byte{0xff}, 100))
+
+	ToGoFuzz(Slice(1, 5, Int(-10, 10)))(f, func(t *testing.T, s []int) {
+		if len(s) < 1 || len(s) >= 5 {
+			t.Fatalf("unexpected length %v", len(s))
+		}
+		for _, v := range s {
+			if v < -10 || v > 10 {
+				t.Fatalf("%v is out of range", v)
+			}
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package fuzz
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/quenbyako/ext/slices"
+)
+
+// IntOption configures signed integer fuzzers.
+type IntOption func(*intOptions)
+
+type intOptions struct {
+	edgeChance float64
+}
+
+// WithEdges makes fuzzer return edge values (min, max, 0, ±1 and neighbours of
+// bounds, if they are in range) with given chance (0 to 1). Bugs usually hide
+// around these values, but uniform generation almost never hits them.
+func WithEdges(chance float64) IntOption { return func(o *intOptions) { o.edgeChance = chance } }
+
+// Int64 generates integers in range [min, max], both bounds are inclusive,
+// so full int64 range is supported.
+func Int64(min, max int64, opts ...IntOption) Fuzzer[int64] {
+	if min > max {
+		panic(fmt.Sprintf("min > max: %v > %v", min, max))
+	}
+
+	var o intOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	edges := edgeValues(min, max)
+	pickEdge := Bool(o.edgeChance)
+	span := uint64(max) - uint64(min) // two's complement makes it correct for negative bounds
+
+	return func(seed io.Reader) int64 {
+		if o.edgeChance > 0 && pickEdge(seed) {
+			return edges[uint64n(seed, uint64(len(edges)-1))]
+		}
+
+		return int64(uint64(min) + uint64n(seed, span))
+	}
+}
+
+// Int is like Int64, but for int type.
+func Int(min, max int, opts ...IntOption) Fuzzer[int] {
+	f := Int64(int64(min), int64(max), opts...)
+	return func(seed io.Reader) int { return int(f(seed)) }
+}
+
+// Int32 is like Int64, but for int32 type.
+func Int32(min, max int32, opts ...IntOption) Fuzzer[int32] {
+	f := Int64(int64(min), int64(max), opts...)
+	return func(seed io.Reader) int32 { return int32(f(seed)) }
+}
+
+// edgeValues returns unique edge values of range [min, max].
+func edgeValues(min, max int64) []int64 {
+	candidates := []int64{min, max, 0, 1, -1}
+	if min < math.MaxInt64 {
+		candidates = append(candidates, min+1)
+	}
+	if max > math.MinInt64 {
+		candidates = append(candidates, max-1)
+	}
+
+	res := make([]int64, 0, len(candidates))
+	for _, v := range candidates {
+		if v >= min && v <= max && !slices.Contains(res, v) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+// readUint64 reads uniformly distributed 64-bit number from seed.
+func readUint64(seed io.Reader) uint64 {
+	var buf [8]byte
+	if _, err := io.ReadFull(seed, buf[:]); err != nil {
+		panic(err)
+	}
+
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// uint64n returns uniformly distributed number in range [0, n], both bounds
+// are inclusive.
+func uint64n(seed io.Reader, n uint64) uint64 {
+	if n == math.MaxUint64 {
+		return readUint64(seed)
+	}
+
+	// Lemire's multiply-shift method with rejection to avoid modulo bias.
+	bound := n + 1
+	hi, lo := bits.Mul64(readUint64(seed), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(readUint64(seed), bound)
+		}
+	}
+
+	return hi
+}
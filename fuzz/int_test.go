@@ -0,0 +1,85 @@
+package fuzz_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestInt64(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		min, max int64
+	}{
+		{"single value", 5, 5},
+		{"small", -2, 2},
+		{"negative", math.MinInt64, math.MinInt64 + 3},
+		{"positive", math.MaxInt64 - 3, math.MaxInt64},
+		{"full range", math.MinInt64, math.MaxInt64},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := Int64(tt.min, tt.max), Seed(1)
+			seen := map[int64]bool{}
+			for i := 0; i < 1000; i++ {
+				v := f(seed)
+				if v < tt.min || v > tt.max {
+					t.Fatalf("%v is out of range [%v, %v]", v, tt.min, tt.max)
+				}
+				seen[v] = true
+			}
+			if uint64(tt.max-tt.min) < 10 && !(seen[tt.min] && seen[tt.max]) {
+				t.Errorf("bounds are never generated: %v", seen)
+			}
+		})
+	}
+
+	t.Run("invalid range", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+		Int64(1, 0)
+	})
+}
+
+func TestInt64_WithEdges(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		min, max int64
+		edges    []int64
+	}{
+		{"around zero", -100, 100, []int64{-100, -99, -1, 0, 1, 99, 100}},
+		{"positive", 10, 20, []int64{10, 11, 19, 20}},
+		{"full range", math.MinInt64, math.MaxInt64, []int64{
+			math.MinInt64, math.MinInt64 + 1, -1, 0, 1, math.MaxInt64 - 1, math.MaxInt64,
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := Int64(tt.min, tt.max, WithEdges(1)), Seed(1)
+			seen := map[int64]bool{}
+			for i := 0; i < 1000; i++ {
+				seen[f(seed)] = true
+			}
+			if len(seen) != len(tt.edges) {
+				t.Errorf("got %v, want only %v", seen, tt.edges)
+			}
+			for _, e := range tt.edges {
+				if !seen[e] {
+					t.Errorf("edge %v is never generated", e)
+				}
+			}
+		})
+	}
+}
+
+func TestInt_Deterministic(t *testing.T) {
+	f := Int(-1000, 1000)
+	a, b := Seed(42), Seed(42)
+	for i := 0; i < 100; i++ {
+		if x, y := f(a), f(b); x != y {
+			t.Fatalf("same seed generated %v and %v", x, y)
+		}
+	}
+}
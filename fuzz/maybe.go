@@ -0,0 +1,42 @@
+package fuzz
+
+import "io"
+
+// nonZeroRetries limits attempts of NonZero to generate non-zero value.
+const nonZeroRetries = 100
+
+// Optional is a value, which could be absent. Unlike nil pointer, it
+// distinguishes absent value from present zero value, so it models optional
+// API fields precisely.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+// Get returns value and whether it is present.
+func (o Optional[T]) Get() (T, bool) { return o.Value, o.Present }
+
+// Maybe generates present values (generated by f) with presentChance (0 to 1),
+// otherwise value is absent and f is not called.
+func Maybe[T any](presentChance float64, f Fuzzer[T]) Fuzzer[Optional[T]] {
+	return func(seed io.Reader) Optional[T] {
+		if !Bool(presentChance)(seed) {
+			return Optional[T]{}
+		}
+
+		return Optional[T]{Value: f(seed), Present: true}
+	}
+}
+
+// Zero always returns zero value of T.
+func Zero[T any]() Fuzzer[T] {
+	var zero T
+	return Const(zero)
+}
+
+// NonZero generates values with f, skipping zero ones. It panics, if f returns
+// only zero values (see [Filter]).
+func NonZero[T comparable](f Fuzzer[T]) Fuzzer[T] {
+	var zero T
+	return Filter(f, func(v T) bool { return v != zero }, nonZeroRetries)
+}
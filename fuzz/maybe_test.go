@@ -0,0 +1,69 @@
+package fuzz_test
+
+import (
+	"io"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestMaybe(t *testing.T) {
+	var calls int
+	counted := func(seed io.Reader) int { calls++; return Int(1, 10)(seed) }
+
+	seed := Seed(1)
+	for i := 0; i < 100; i++ {
+		if v, ok := Maybe(0, counted)(seed).Get(); ok || v != 0 {
+			t.Fatalf("expected absent value, got %v, %v", v, ok)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("fuzzer must not be called for absent values, called %v times", calls)
+	}
+
+	for i := 0; i < 100; i++ {
+		if v, ok := Maybe(1, counted)(seed).Get(); !ok || v < 1 || v > 10 {
+			t.Fatalf("expected present value, got %v, %v", v, ok)
+		}
+	}
+
+	// present zero is different from absent value
+	if o := Maybe(1, Zero[int]())(seed); !o.Present || o.Value != 0 {
+		t.Errorf("expected present zero, got %+v", o)
+	}
+
+	var present int
+	for i := 0; i < 1000; i++ {
+		if Maybe(0.3, counted)(seed).Present {
+			present++
+		}
+	}
+	if present < 250 || present > 350 {
+		t.Errorf("%v of 1000 values are present", present)
+	}
+}
+
+func TestZero(t *testing.T) {
+	if v := Zero[*int]()(Seed(1)); v != nil {
+		t.Errorf("expected nil, got %v", v)
+	}
+	if v := Zero[struct{ A string }]()(Seed(1)); v.A != "" {
+		t.Errorf("expected zero struct, got %+v", v)
+	}
+}
+
+func TestNonZero(t *testing.T) {
+	f, seed := NonZero(Int(0, 2)), Seed(1)
+	for i := 0; i < 100; i++ {
+		if v := f(seed); v == 0 {
+			t.Fatal("unexpected zero value")
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on fuzzer returning only zero values")
+		}
+	}()
+	NonZero(Const(0))(seed)
+}
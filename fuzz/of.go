@@ -0,0 +1,48 @@
+package fuzz
+
+import (
+	"io"
+	"sort"
+)
+
+// Of picks one of values uniformly. It panics, if no values are passed.
+func Of[T any](values ...T) Fuzzer[T] {
+	if len(values) == 0 {
+		panic("no values to pick from")
+	}
+
+	return func(seed io.Reader) T { return pick(seed, values) }
+}
+
+// Weighted is a value with relative chance to be picked by [OfWeighted].
+type Weighted[T any] struct {
+	Value  T
+	Weight uint
+}
+
+// OfWeighted picks one of values with probability, proportional to its weight.
+// Values with zero weight are never picked. It panics, if total weight is zero.
+//
+//	fuzz.OfWeighted(
+//		fuzz.Weighted[string]{"active", 8},
+//		fuzz.Weighted[string]{"banned", 1},
+//		fuzz.Weighted[string]{"deleted", 1},
+//	)
+func OfWeighted[T any](pairs ...Weighted[T]) Fuzzer[T] {
+	cumulative := make([]uint64, len(pairs))
+	var total uint64
+	for i, p := range pairs {
+		total += uint64(p.Weight)
+		cumulative[i] = total
+	}
+	if total == 0 {
+		panic("total weight must be positive")
+	}
+
+	return func(seed io.Reader) T {
+		n := uint64n(seed, total-1)
+		i := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > n })
+
+		return pairs[i].Value
+	}
+}
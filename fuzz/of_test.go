@@ -0,0 +1,66 @@
+package fuzz_test
+
+import (
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestOf(t *testing.T) {
+	f, seed := Of("a", "b", "c"), Seed(1)
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		counts[f(seed)]++
+	}
+	if len(counts) != 3 {
+		t.Fatalf("unexpected values: %v", counts)
+	}
+	for v, n := range counts {
+		if n < 800 || n > 1200 {
+			t.Errorf("%q is picked %v times of 3000, distribution is not uniform", v, n)
+		}
+	}
+
+	if v := Of(42)(seed); v != 42 {
+		t.Errorf("expected the only value, got %v", v)
+	}
+}
+
+func TestOfWeighted(t *testing.T) {
+	f, seed := OfWeighted(
+		Weighted[string]{"active", 8},
+		Weighted[string]{"never", 0},
+		Weighted[string]{"banned", 2},
+	), Seed(1)
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		counts[f(seed)]++
+	}
+	if n := counts["never"]; n != 0 {
+		t.Errorf("value with zero weight is picked %v times", n)
+	}
+	if n := counts["active"]; n < 7600 || n > 8400 {
+		t.Errorf("value with weight 8 of 10 is picked %v times of 10000", n)
+	}
+	if n := counts["banned"]; n < 1600 || n > 2400 {
+		t.Errorf("value with weight 2 of 10 is picked %v times of 10000", n)
+	}
+}
+
+func TestOf_Panics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"no values":         func() { Of[int]() },
+		"no pairs":          func() { OfWeighted[int]() },
+		"zero total weight": func() { OfWeighted(Weighted[int]{1, 0}, Weighted[int]{2, 0}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		})
+	}
+}
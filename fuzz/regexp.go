@@ -0,0 +1,159 @@
+package fuzz
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// maxRegexpRepeat limits unbounded repetitions (*, + and {n,}) in FromRegexp.
+const maxRegexpRepeat = 10
+
+// FromRegexp generates strings, matching pattern (Perl syntax, same as regexp
+// package). Unbounded repetitions are limited to 10 additional items.
+//
+// Anchors and word boundaries are ignored, so generated string could fail to
+// match patterns like `a\bb`, which require impossible combinations.
+func FromRegexp(pattern string) (Fuzzer[string], error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := compileRegexp(re.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", pattern, err)
+	}
+
+	return func(seed io.Reader) string {
+		var b strings.Builder
+		gen(seed, &b)
+		return b.String()
+	}, nil
+}
+
+type regexpGen func(seed io.Reader, b *strings.Builder)
+
+func compileRegexp(re *syntax.Regexp) (regexpGen, error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return nil, errors.New("pattern matches nothing")
+
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return func(io.Reader, *strings.Builder) {}, nil
+
+	case syntax.OpLiteral:
+		runes, fold := re.Rune, re.Flags&syntax.FoldCase != 0
+		return func(seed io.Reader, b *strings.Builder) {
+			for _, r := range runes {
+				if fold && uint64n(seed, 1) == 0 {
+					r = unicode.SimpleFold(r)
+				}
+				b.WriteRune(r)
+			}
+		}, nil
+
+	case syntax.OpCharClass:
+		return compileCharClass(re.Rune)
+
+	case syntax.OpAnyCharNotNL:
+		return compileCharClass([]rune{' ', '~'})
+
+	case syntax.OpAnyChar:
+		return compileCharClass([]rune{'\n', '\n', ' ', '~'})
+
+	case syntax.OpCapture:
+		return compileRegexp(re.Sub[0])
+
+	case syntax.OpStar:
+		return compileRepeat(re.Sub[0], 0, maxRegexpRepeat)
+
+	case syntax.OpPlus:
+		return compileRepeat(re.Sub[0], 1, 1+maxRegexpRepeat)
+
+	case syntax.OpQuest:
+		return compileRepeat(re.Sub[0], 0, 1)
+
+	case syntax.OpRepeat:
+		max := re.Max
+		if max < 0 {
+			max = re.Min + maxRegexpRepeat
+		}
+		return compileRepeat(re.Sub[0], re.Min, max)
+
+	case syntax.OpConcat, syntax.OpAlternate:
+		subs := make([]regexpGen, 0, len(re.Sub))
+		for _, sub := range re.Sub {
+			gen, err := compileRegexp(sub)
+			if err != nil {
+				if re.Op == syntax.OpAlternate {
+					continue // other alternatives could match
+				}
+				return nil, err
+			}
+			subs = append(subs, gen)
+		}
+		if len(subs) == 0 {
+			return nil, errors.New("pattern matches nothing")
+		}
+
+		if re.Op == syntax.OpConcat {
+			return func(seed io.Reader, b *strings.Builder) {
+				for _, gen := range subs {
+					gen(seed, b)
+				}
+			}, nil
+		}
+		return func(seed io.Reader, b *strings.Builder) {
+			subs[uint64n(seed, uint64(len(subs)-1))](seed, b)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported regexp operation %v", re.Op)
+	}
+}
+
+func compileRepeat(re *syntax.Regexp, min, max int) (regexpGen, error) {
+	gen, err := compileRegexp(re)
+	if err != nil {
+		if min == 0 {
+			return func(io.Reader, *strings.Builder) {}, nil
+		}
+		return nil, err
+	}
+
+	return func(seed io.Reader, b *strings.Builder) {
+		n := min + int(uint64n(seed, uint64(max-min)))
+		for i := 0; i < n; i++ {
+			gen(seed, b)
+		}
+	}, nil
+}
+
+// compileCharClass generates runes from class, defined as pairs of inclusive
+// ranges [lo, hi], same as in syntax.Regexp.
+func compileCharClass(class []rune) (regexpGen, error) {
+	var total uint64
+	for i := 0; i < len(class); i += 2 {
+		total += uint64(class[i+1]-class[i]) + 1
+	}
+	if total == 0 {
+		return nil, errors.New("empty character class")
+	}
+
+	return func(seed io.Reader, b *strings.Builder) {
+		n := uint64n(seed, total-1)
+		for i := 0; i < len(class); i += 2 {
+			if size := uint64(class[i+1]-class[i]) + 1; n >= size {
+				n -= size
+				continue
+			}
+			b.WriteRune(class[i] + rune(n))
+			return
+		}
+	}, nil
+}
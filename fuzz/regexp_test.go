@@ -0,0 +1,50 @@
+package fuzz_test
+
+import (
+	"regexp"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestFromRegexp(t *testing.T) {
+	for _, pattern := range []string{
+		`abc`,
+		`[a-z]+`,
+		`a*b?c{2,3}d{2,}`,
+		`(foo|bar)-\d{3}`,
+		`(?i)hello`,
+		`[^a-z]`,
+		`.+`,
+		`(?s).*`,
+		`^\w+@\w+\.(com|org)$`,
+		`[\p{Greek}]{1,5}`,
+		`x|[^\x00-\x{10FFFF}]`,
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			f, err := FromRegexp(pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			re, seed := regexp.MustCompile(`^(?:`+pattern+`)$`), Seed(1)
+			for i := 0; i < 100; i++ {
+				if s := f(seed); !re.MatchString(s) {
+					t.Fatalf("%q doesn't match", s)
+				}
+			}
+		})
+	}
+
+	for _, pattern := range []string{
+		`[^\x00-\x{10FFFF}]`,
+		`a[^\x00-\x{10FFFF}]+`,
+		`(`,
+	} {
+		t.Run(pattern, func(t *testing.T) {
+			if _, err := FromRegexp(pattern); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
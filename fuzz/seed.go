@@ -0,0 +1,85 @@
+package fuzz
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// SeedEnv is an environment variable, which overrides seed, chosen by
+// [TestSeed], so failed test could be replayed:
+//
+//	FUZZ_SEED=12345 go test -run TestFailed ./...
+const SeedEnv = "FUZZ_SEED"
+
+// Seed returns deterministic infinite stream of pseudo-random bytes, generated
+// by splitmix64. Same n always produces same stream, so fuzzers, called with
+// it, generate same values. Stream is fast, but it's not cryptographically
+// secure.
+func Seed(n uint64) io.Reader { return &splitmix64{state: n, off: 8} }
+
+// TestSeed returns deterministic seed source for test. Seed is random, unless
+// it's set with FUZZ_SEED environment variable. If test fails, seed is logged
+// to replay it.
+func TestSeed(t testing.TB) io.Reader {
+	t.Helper()
+
+	n, err := envSeed()
+	if err != nil {
+		t.Fatalf("invalid %v: %v", SeedEnv, err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("replay with %v=%v", SeedEnv, n)
+		}
+	})
+
+	return Seed(n)
+}
+
+func envSeed() (uint64, error) {
+	if s, ok := os.LookupEnv(SeedEnv); ok {
+		return strconv.ParseUint(s, 10, 64)
+	}
+
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+type splitmix64 struct {
+	state uint64
+	buf   [8]byte
+	off   int // unread bytes start in buf, 8 means buf is empty
+}
+
+func (s *splitmix64) next() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+
+	return z ^ z>>31
+}
+
+func (s *splitmix64) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if s.off == len(s.buf) {
+			binary.LittleEndian.PutUint64(s.buf[:], s.next())
+			s.off = 0
+		}
+		c := copy(p[n:], s.buf[s.off:])
+		s.off += c
+		n += c
+	}
+
+	return n, nil
+}
@@ -0,0 +1,49 @@
+package fuzz_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestSeed_Stream(t *testing.T) {
+	read := func(r io.Reader, n int) []byte {
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf
+	}
+
+	if a, b := read(Seed(42), 100), read(Seed(42), 100); !bytes.Equal(a, b) {
+		t.Error("same seed must produce same stream")
+	}
+	if a, b := read(Seed(42), 100), read(Seed(43), 100); bytes.Equal(a, b) {
+		t.Error("different seeds must produce different streams")
+	}
+
+	// stream doesn't depend on sizes of reads
+	want := read(Seed(1), 100)
+	var got []byte
+	r := Seed(1)
+	for _, n := range []int{1, 3, 8, 0, 13, 75} {
+		got = append(got, read(r, n)...)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("chunked reads: got %x, want %x", got, want)
+	}
+}
+
+func TestSeed_Env(t *testing.T) {
+	t.Setenv(SeedEnv, "12345")
+
+	f := Int(0, 1<<30)
+	a, b := TestSeed(t), Seed(12345)
+	for i := 0; i < 10; i++ {
+		if x, y := f(a), f(b); x != y {
+			t.Fatalf("TestSeed must use %v: got %v, want %v", SeedEnv, x, y)
+		}
+	}
+}
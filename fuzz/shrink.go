@@ -0,0 +1,161 @@
+package fuzz
+
+import (
+	"math"
+
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/maps"
+	"github.com/quenbyako/ext/slices"
+)
+
+// maxShrinkSteps limits number of checks in Shrink, so slow properties or
+// huge values don't hang the test.
+const maxShrinkSteps = 1000
+
+// Shrinker returns candidates, which are "smaller" than value, most aggressive
+// ones first. Shrinker must return nothing for minimal value, and repeated
+// shrinking must eventually reach it, otherwise Shrink stops only after step
+// limit.
+type Shrinker[T any] func(T) []T
+
+// Shrink minimizes failing value v: it greedily replaces v with the first
+// candidate, that still fails, until no candidate fails. Number of fails calls
+// is limited, so returned value is not always minimal, but it's never larger
+// than v.
+func Shrink[T any](v T, s Shrinker[T], fails func(T) bool) T {
+	steps := 0
+	for {
+		shrunk := false
+		for _, c := range s(v) {
+			if steps++; steps > maxShrinkSteps {
+				return v
+			}
+			if fails(c) {
+				v, shrunk = c, true
+				break
+			}
+		}
+		if !shrunk {
+			return v
+		}
+	}
+}
+
+// ShrinkInt shrinks integers towards zero, approaching it with halved steps
+// (x-x, x-x/2, x-x/4, ..., x-1), like QuickCheck does. Negative values are
+// shrunk to their absolute value too.
+func ShrinkInt[T cmp.Integer]() Shrinker[T] {
+	return func(x T) []T {
+		var res []T
+		for i := x; i != 0; i /= 2 {
+			res = append(res, x-i)
+		}
+		if x < 0 && -x > 0 {
+			res = append(res, -x)
+		}
+
+		return res
+	}
+}
+
+// ShrinkFloat64 shrinks floats towards zero, preferring integers. NaN and
+// infinities are shrunk to zero.
+func ShrinkFloat64() Shrinker[float64] {
+	return func(x float64) []float64 {
+		switch {
+		case x == 0:
+			return nil
+		case math.IsNaN(x) || math.IsInf(x, 0):
+			return []float64{0}
+		}
+
+		res := []float64{0}
+		if x < 0 {
+			res = append(res, -x)
+		}
+		if t := math.Trunc(x); t != x && t != 0 {
+			res = append(res, t)
+		}
+		if h := x / 2; h != x && h != 0 {
+			res = append(res, h)
+		}
+
+		return res
+	}
+}
+
+// ShrinkString shrinks strings by removing chunks of runes.
+func ShrinkString() Shrinker[string] {
+	shrink := ShrinkSlice[rune](nil)
+	return func(s string) []string {
+		runes := shrink([]rune(s))
+		res := make([]string, len(runes))
+		for i, r := range runes {
+			res[i] = string(r)
+		}
+
+		return res
+	}
+}
+
+// ShrinkSlice shrinks slices by removing chunks of items (halves first, then
+// quarters, down to single items), then by shrinking items one by one with
+// elem. elem may be nil, then items are not shrunk.
+func ShrinkSlice[T any](elem Shrinker[T]) Shrinker[[]T] {
+	return func(s []T) [][]T {
+		if len(s) == 0 {
+			return nil
+		}
+
+		res := [][]T{nil}
+		for size := len(s) / 2; size > 0; size /= 2 {
+			for i := 0; i+size <= len(s); i += size {
+				c := make([]T, 0, len(s)-size)
+				res = append(res, append(append(c, s[:i]...), s[i+size:]...))
+			}
+		}
+		if elem == nil {
+			return res
+		}
+
+		for i, item := range s {
+			for _, shrunk := range elem(item) {
+				c := slices.Clone(s)
+				c[i] = shrunk
+				res = append(res, c)
+			}
+		}
+
+		return res
+	}
+}
+
+// ShrinkMap shrinks maps by removing keys one by one, then by shrinking values
+// with val. val may be nil, then values are not shrunk.
+func ShrinkMap[K comparable, V any](val Shrinker[V]) Shrinker[map[K]V] {
+	return func(m map[K]V) []map[K]V {
+		if len(m) == 0 {
+			return nil
+		}
+
+		res := []map[K]V{{}}
+		for k := range m {
+			c := maps.Clone(m)
+			delete(c, k)
+			res = append(res, c)
+		}
+		if val == nil {
+			return res
+		}
+
+		for k, v := range m {
+			for _, shrunk := range val(v) {
+				c := maps.Clone(m)
+				c[k] = shrunk
+				res = append(res, c)
+			}
+		}
+
+		return res
+	}
+}
@@ -0,0 +1,117 @@
+package fuzz_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestShrink(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		if got := Shrink(987654, ShrinkInt[int](), func(x int) bool { return x > 1234 }); got != 1235 {
+			t.Errorf("got %v, want 1235", got)
+		}
+	})
+
+	t.Run("negative int", func(t *testing.T) {
+		if got := Shrink(-987654, ShrinkInt[int](), func(x int) bool { return x < -10 || x > 10 }); got != -11 && got != 11 {
+			t.Errorf("got %v, want ±11", got)
+		}
+	})
+
+	t.Run("min int", func(t *testing.T) {
+		if got := Shrink(int8(math.MinInt8), ShrinkInt[int8](), func(x int8) bool { return x != 0 }); got != 1 && got != -1 {
+			t.Errorf("got %v, want ±1", got)
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		if got := Shrink(1234.5678, ShrinkFloat64(), func(x float64) bool { return x > 1 }); got <= 1 || got > 2 {
+			t.Errorf("got %v, want value in (1, 2]", got)
+		}
+	})
+
+	t.Run("special float", func(t *testing.T) {
+		for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			if got := Shrink(v, ShrinkFloat64(), func(float64) bool { return true }); got != 0 {
+				t.Errorf("%v: got %v, want 0", v, got)
+			}
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		got := Shrink("some long string with x inside", ShrinkString(), func(s string) bool {
+			return strings.Contains(s, "x")
+		})
+		if got != "x" {
+			t.Errorf("got %q, want %q", got, "x")
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		got := Shrink([]int{5, 100, 3, 42, 7}, ShrinkSlice(ShrinkInt[int]()), func(s []int) bool {
+			sum := 0
+			for _, v := range s {
+				sum += v
+			}
+			return sum >= 10
+		})
+		if !reflect.DeepEqual(got, []int{10}) {
+			t.Errorf("got %v, want [10]", got)
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		got := Shrink(map[string]int{"a": 1, "b": 200, "c": 3}, ShrinkMap[string](ShrinkInt[int]()), func(m map[string]int) bool {
+			return m["b"] > 50
+		})
+		if !reflect.DeepEqual(got, map[string]int{"b": 51}) {
+			t.Errorf("got %v, want map[b:51]", got)
+		}
+	})
+
+	t.Run("never fails", func(t *testing.T) {
+		if got := Shrink(100, ShrinkInt[int](), func(int) bool { return false }); got != 100 {
+			t.Errorf("got %v, want original value", got)
+		}
+	})
+
+	t.Run("step limit", func(t *testing.T) {
+		calls := 0
+		endless := func(x int) []int { return []int{x + 1} }
+		Shrink(0, endless, func(int) bool { calls++; return true })
+		if calls > 1000 {
+			t.Errorf("fails called %v times", calls)
+		}
+	})
+}
+
+func TestShrinkInt(t *testing.T) {
+	for _, tt := range []struct {
+		x    int
+		want []int
+	}{
+		{0, nil},
+		{1, []int{0}},
+		{10, []int{0, 5, 8, 9}},
+		{-4, []int{0, -2, -3, 4}},
+	} {
+		if got := ShrinkInt[int]()(tt.x); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ShrinkInt(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestShrinkSlice(t *testing.T) {
+	if got := ShrinkSlice[int](nil)(nil); got != nil {
+		t.Errorf("empty slice must not be shrunk, got %v", got)
+	}
+
+	want := [][]int{nil, {3, 4}, {1, 2}, {2, 3, 4}, {1, 3, 4}, {1, 2, 4}, {1, 2, 3}}
+	if got := ShrinkSlice[int](nil)([]int{1, 2, 3, 4}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
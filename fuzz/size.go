@@ -0,0 +1,66 @@
+package fuzz
+
+import (
+	"io"
+	"math"
+)
+
+// SizeOption configures length distribution of collections, generated by
+// [Slice], [Map] and [String].
+type SizeOption func(*sizeOptions)
+
+type sizeOptions struct {
+	geometricMean float64
+	emptyChance   float64
+}
+
+// WithGeometricSize makes length distributed geometrically with given mean
+// above min (truncated by max), instead of uniformly. Short collections are
+// generated much more often, so edge cases of one or two items are covered.
+func WithGeometricSize(mean float64) SizeOption {
+	return func(o *sizeOptions) { o.geometricMean = mean }
+}
+
+// WithEmpty makes fuzzer return collection of minimal length (empty, if min is
+// 0) with given chance (0 to 1).
+func WithEmpty(chance float64) SizeOption { return func(o *sizeOptions) { o.emptyChance = chance } }
+
+func newSizeOptions(opts []SizeOption) sizeOptions {
+	var o sizeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// length returns length in range [min, max). If min equals max, it's min.
+func (o sizeOptions) length(seed io.Reader, min, max uint64) uint64 {
+	if o.emptyChance > 0 && Bool(o.emptyChance)(seed) {
+		return min
+	}
+	if o.geometricMean <= 0 || min >= max {
+		return Uint64(min, max)(seed)
+	}
+
+	// Inverse transform sampling: k = floor(ln(u) / ln(1-p)), p = 1/(mean+1).
+	k := math.Floor(math.Log(1-unitFloat64(seed)) / math.Log1p(-1/(o.geometricMean+1)))
+	if k >= float64(max-min-1) {
+		return max - 1
+	}
+
+	return min + uint64(k)
+}
+
+// PtrOption configures [Ptr] fuzzer.
+type PtrOption func(*ptrOptions)
+
+type ptrOptions struct {
+	zeroChance float64
+}
+
+// WithZeroValue makes [Ptr] return pointer to zero value of T with given
+// chance (0 to 1), if pointer is not nil.
+func WithZeroValue(chance float64) PtrOption {
+	return func(o *ptrOptions) { o.zeroChance = chance }
+}
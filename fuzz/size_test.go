@@ -0,0 +1,86 @@
+package fuzz_test
+
+import (
+	"testing"
+
+	. "github.com/quenbyako/ext/fuzz"
+)
+
+func TestWithEmpty(t *testing.T) {
+	seed := Seed(1)
+	for i := 0; i < 100; i++ {
+		if s := Slice(0, 10, Int(0, 9), WithEmpty(1))(seed); len(s) != 0 {
+			t.Fatalf("expected empty slice, got %v", s)
+		}
+		if s := String(3, 10, WithEmpty(1))(seed); len(s) != 3 {
+			t.Fatalf("expected string of minimal length, got %q", s)
+		}
+	}
+
+	empty, f := 0, Slice(0, 10, Int(0, 9), WithEmpty(0.5))
+	for i := 0; i < 1000; i++ {
+		if len(f(seed)) == 0 {
+			empty++
+		}
+	}
+	// half of values are empty by option, and some of the rest by chance
+	if empty < 450 || empty > 650 {
+		t.Errorf("%v of 1000 slices are empty", empty)
+	}
+}
+
+func TestWithGeometricSize(t *testing.T) {
+	f, seed := Slice(2, 50, Int(0, 9), WithGeometricSize(2)), Seed(1)
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		s := f(seed)
+		if len(s) < 2 || len(s) >= 50 {
+			t.Fatalf("unexpected length %v", len(s))
+		}
+		counts[len(s)]++
+	}
+	// p = 1/3, so minimal length has 1/3 chance, and each next is less likely
+	if n := counts[2]; n < 280 || n > 390 {
+		t.Errorf("minimal length is generated %v times of 1000", n)
+	}
+	if counts[2] <= counts[3] || counts[3] <= counts[5] {
+		t.Errorf("short lengths must be more frequent: %v", counts)
+	}
+
+	// values above max are truncated
+	for i := 0; i < 100; i++ {
+		if s := Slice(0, 3, Int(0, 9), WithGeometricSize(100))(seed); len(s) >= 3 {
+			t.Fatalf("unexpected length %v", len(s))
+		}
+	}
+
+	// min equal to max is always min
+	for _, opts := range [][]SizeOption{nil, {WithGeometricSize(2)}} {
+		if s := Slice(4, 4, Int(0, 9), opts...)(seed); len(s) != 4 {
+			t.Errorf("expected length 4, got %v", len(s))
+		}
+	}
+}
+
+func TestWithZeroValue(t *testing.T) {
+	f, seed := Ptr(0.5, Int(1, 10), WithZeroValue(1)), Seed(1)
+	var nils int
+	for i := 0; i < 1000; i++ {
+		switch v := f(seed); {
+		case v == nil:
+			nils++
+		case *v != 0:
+			t.Fatalf("expected pointer to zero, got %v", *v)
+		}
+	}
+	if nils < 400 || nils > 600 {
+		t.Errorf("%v of 1000 pointers are nil", nils)
+	}
+
+	f = Ptr(0, Int(1, 10), WithZeroValue(0))
+	for i := 0; i < 100; i++ {
+		if v := f(seed); v == nil || *v == 0 {
+			t.Fatalf("expected pointer to non-zero value, got %v", v)
+		}
+	}
+}
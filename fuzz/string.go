@@ -0,0 +1,60 @@
+package fuzz
+
+import (
+	"io"
+	"sort"
+
+	"github.com/quenbyako/ext/span"
+)
+
+// StringFrom generates strings with length (in runes) in range [min, max),
+// each rune is picked uniformly from runes of s, so generated strings respect
+// character classes:
+//
+//	ident := span.NewRune(span.NewBoundII('a', 'z'), span.NewBoundII('0', '9'), span.NewBoundII('_', '_'))
+//	fuzz.StringFrom(ident, 1, 32)
+//
+// It panics if span has no runes.
+func StringFrom(s span.Span[rune], min, max uint64) Fuzzer[string] {
+	var ranges []runeRange
+	var total uint64
+	for _, b := range s.Bounds() {
+		lo, hi := b.Lo.Value, b.Hi.Value
+		if !b.Lo.Included {
+			lo++
+		}
+		if !b.Hi.Included {
+			hi--
+		}
+		if lo > hi {
+			continue
+		}
+
+		ranges = append(ranges, runeRange{lo: lo, offset: total})
+		total += uint64(hi-lo) + 1
+	}
+	if total == 0 {
+		panic("span has no runes")
+	}
+
+	length := Uint64(min, max)
+
+	return func(seed io.Reader) string {
+		res := make([]rune, length(seed))
+		for i := range res {
+			n := uint64n(seed, total-1)
+			// the last range, which starts before n
+			j := sort.Search(len(ranges), func(j int) bool { return ranges[j].offset > n }) - 1
+			res[i] = ranges[j].lo + rune(n-ranges[j].offset)
+		}
+
+		return string(res)
+	}
+}
+
+// runeRange is an inclusive range of runes, starting from lo. offset is the
+// count of runes in all previous ranges.
+type runeRange struct {
+	lo     rune
+	offset uint64
+}
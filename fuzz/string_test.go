@@ -0,0 +1,67 @@
+package fuzz_test
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	. "github.com/quenbyako/ext/fuzz"
+	"github.com/quenbyako/ext/span"
+)
+
+func TestStringFrom(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		s        span.Span[rune]
+		min, max uint64
+		valid    func(rune) bool
+	}{
+		{"single range", span.NewRune(span.NewBoundII('a', 'z')), 1, 10, func(r rune) bool {
+			return r >= 'a' && r <= 'z'
+		}},
+		{"several ranges", span.NewRune(span.NewBoundII('a', 'c'), span.NewBoundII('0', '1'), span.NewBoundII('_', '_')), 0, 20, func(r rune) bool {
+			return r >= 'a' && r <= 'c' || r == '0' || r == '1' || r == '_'
+		}},
+		{"excluded bounds", span.NewRune(span.NewBoundXX('a', 'd')), 5, 6, func(r rune) bool {
+			return r == 'b' || r == 'c'
+		}},
+		{"multibyte", span.NewRune(span.NewBoundII('α', 'ω')), 3, 4, func(r rune) bool {
+			return r >= 'α' && r <= 'ω'
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f, seed := StringFrom(tt.s, tt.min, tt.max), Seed(1)
+			seenRunes, seenLen := map[rune]bool{}, map[uint64]bool{}
+			for i := 0; i < 1000; i++ {
+				s := f(seed)
+				n := uint64(utf8.RuneCountInString(s))
+				if n < tt.min || n >= tt.max {
+					t.Fatalf("%q: length %v is out of range [%v, %v)", s, n, tt.min, tt.max)
+				}
+				seenLen[n] = true
+				for _, r := range s {
+					if !tt.valid(r) {
+						t.Fatalf("%q: unexpected rune %q", s, r)
+					}
+					seenRunes[r] = true
+				}
+			}
+			if len(seenLen) != int(tt.max-tt.min) {
+				t.Errorf("not all lengths are generated: %v", seenLen)
+			}
+			for r := rune(0); r < 0x500; r++ {
+				if tt.valid(r) && !seenRunes[r] {
+					t.Errorf("rune %q is never generated", r)
+				}
+			}
+		})
+	}
+
+	t.Run("empty span", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic")
+			}
+		}()
+		StringFrom(span.NewRune(span.NewBoundXX('a', 'b')), 1, 2)
+	})
+}
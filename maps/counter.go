@@ -0,0 +1,53 @@
+package maps
+
+import (
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/slices"
+)
+
+// Counter counts occurrences of keys. It's a named map[K]int, so you can
+// build it from [slices.Counts] result with simple conversion:
+//
+//	c := maps.Counter[string](slices.Counts(words))
+//
+// Keys with zero count are removed from counter.
+type Counter[K comparable] map[K]int
+
+// Add adds n to count of k. n can be negative.
+func (c Counter[K]) Add(k K, n int) {
+	if c[k] += n; c[k] == 0 {
+		delete(c, k)
+	}
+}
+
+// Count returns count of k.
+func (c Counter[K]) Count(k K) int { return c[k] }
+
+// Total returns sum of all counts.
+func (c Counter[K]) Total() (n int) {
+	for _, count := range c {
+		n += count
+	}
+
+	return n
+}
+
+// MostCommon returns n keys with the largest counts, in descending order of
+// counts. Keys with equal counts are in indeterminate order. If n is negative,
+// all keys are returned.
+func (c Counter[K]) MostCommon(n int) []K {
+	if n < 0 {
+		n = len(c)
+	}
+
+	return slices.TopKFunc(Keys(c), n, func(a, b K) int { return cmp.Compare(c[a], c[b]) })
+}
+
+// Merge adds counts of other to c.
+func (c Counter[K]) Merge(other Counter[K]) Counter[K] {
+	for k, n := range other {
+		c.Add(k, n)
+	}
+
+	return c
+}
@@ -0,0 +1,128 @@
+package maps
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Expiring is a map, which entries expire after their TTL. Expired entries
+// are removed lazily, on access, or by [Expiring.Purge], which can be run
+// periodically with [Expiring.StartJanitor].
+//
+// Expiring is safe for concurrent use.
+type Expiring[K comparable, V any] struct {
+	// OnExpire, if set, is called for each expired entry, when it's removed
+	// from map. It's called without lock held, so it's safe to use the map
+	// inside. Set it before first use of map.
+	OnExpire func(K, V)
+
+	mu    sync.Mutex
+	items map[K]expiringEntry[V]
+}
+
+type expiringEntry[V any] struct {
+	value   V
+	expires time.Time // zero means never
+}
+
+func (e expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// NewExpiring creates empty expiring map.
+func NewExpiring[K comparable, V any]() *Expiring[K, V] {
+	return &Expiring[K, V]{items: make(map[K]expiringEntry[V])}
+}
+
+// Set sets value of k without expiration.
+func (m *Expiring[K, V]) Set(k K, v V) { m.SetWithTTL(k, v, 0) }
+
+// SetWithTTL sets value of k, which expires after ttl. Non-positive ttl means
+// no expiration.
+func (m *Expiring[K, V]) SetWithTTL(k K, v V, ttl time.Duration) {
+	entry := expiringEntry[V]{value: v}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[k] = entry
+}
+
+// Get returns value of k, if it exists and not expired yet.
+func (m *Expiring[K, V]) Get(k K) (v V, ok bool) {
+	m.mu.Lock()
+	entry, ok := m.items[k]
+	expired := ok && entry.expired(time.Now())
+	if expired {
+		delete(m.items, k)
+	}
+	m.mu.Unlock()
+
+	if expired {
+		m.expire(k, entry.value)
+		return v, false
+	}
+
+	return entry.value, ok
+}
+
+// Delete removes k from map. OnExpire is not called.
+func (m *Expiring[K, V]) Delete(k K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, k)
+}
+
+// Len returns count of entries in map. Expired entries, which were not removed
+// yet, are counted too.
+func (m *Expiring[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// Purge removes all expired entries.
+func (m *Expiring[K, V]) Purge() {
+	now := time.Now()
+	expired := make(map[K]V)
+
+	m.mu.Lock()
+	for k, entry := range m.items {
+		if entry.expired(now) {
+			expired[k] = entry.value
+			delete(m.items, k)
+		}
+	}
+	m.mu.Unlock()
+
+	for k, v := range expired {
+		m.expire(k, v)
+	}
+}
+
+// StartJanitor runs [Expiring.Purge] every interval in background, until ctx
+// is done.
+func (m *Expiring[K, V]) StartJanitor(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.Purge()
+			}
+		}
+	}()
+}
+
+func (m *Expiring[K, V]) expire(k K, v V) {
+	if m.OnExpire != nil {
+		m.OnExpire(k, v)
+	}
+}
@@ -0,0 +1,53 @@
+package maps
+
+// All returns an iterator over key/value pairs of m. Iteration order is not
+// specified, same as for range over map.
+func All[M ~map[K]V, K comparable, V any](m M) func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq returns an iterator over keys of m. Unlike [Keys], it doesn't
+// allocate a slice.
+func KeysSeq[M ~map[K]V, K comparable, V any](m M) func(yield func(K) bool) {
+	return func(yield func(K) bool) {
+		for k := range m {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over values of m. Unlike [Values], it doesn't
+// allocate a slice.
+func ValuesSeq[M ~map[K]V, K comparable, V any](m M) func(yield func(V) bool) {
+	return func(yield func(V) bool) {
+		for _, v := range m {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Insert adds key/value pairs from seq to m. If a key appears several times,
+// the last value wins.
+func Insert[M ~map[K]V, K comparable, V any](m M, seq func(yield func(K, V) bool)) {
+	seq(func(k K, v V) bool {
+		m[k] = v
+		return true
+	})
+}
+
+// Collect collects key/value pairs from seq into a new map.
+func Collect[K comparable, V any](seq func(yield func(K, V) bool)) map[K]V {
+	m := make(map[K]V)
+	Insert(m, seq)
+	return m
+}
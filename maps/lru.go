@@ -0,0 +1,188 @@
+package maps
+
+import (
+	"sync"
+	"time"
+
+	"github.com/quenbyako/ext/list"
+)
+
+// LRU is a cache with limited capacity, which evicts least recently used
+// entries first. Entries also can have TTL, expired entries are removed
+// lazily, on access. All operations are O(1).
+//
+// LRU is not safe for concurrent use, wrap it with [SyncLRU] if you need it.
+type LRU[K comparable, V any] struct {
+	// OnEvict, if set, is called for each entry removed from cache due to
+	// capacity limit or expiration. It is not called on Delete and Clear.
+	OnEvict func(K, V)
+
+	capacity int
+	ll       *list.List[*lruEntry[K, V]] // front is the most recently used
+	items    map[K]*list.Element[*lruEntry[K, V]]
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero means never
+}
+
+func (e *lruEntry[K, V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// NewLRU creates a new LRU cache. If capacity is not positive, cache size is
+// unlimited, and entries are removed only when they expire.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: capacity,
+		ll:       list.New[*lruEntry[K, V]](),
+		items:    make(map[K]*list.Element[*lruEntry[K, V]]),
+	}
+}
+
+// Set adds or updates value of k without expiration and marks it as recently
+// used.
+func (c *LRU[K, V]) Set(k K, v V) { c.SetWithTTL(k, v, 0) }
+
+// SetWithTTL is like Set, but entry expires after ttl. Non-positive ttl means
+// no expiration.
+func (c *LRU[K, V]) SetWithTTL(k K, v V, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if e, ok := c.items[k]; ok {
+		e.Value.value, e.Value.expires = v, expires
+		c.ll.MoveToFront(e)
+		return
+	}
+
+	c.items[k] = c.ll.PushFront(&lruEntry[K, V]{key: k, value: v, expires: expires})
+	if c.capacity > 0 && c.ll.Len() > c.capacity {
+		c.evict(c.ll.Back())
+	}
+}
+
+func (c *LRU[K, V]) evict(e *list.Element[*lruEntry[K, V]]) {
+	entry := c.ll.Remove(e)
+	delete(c.items, entry.key)
+	if c.OnEvict != nil {
+		c.OnEvict(entry.key, entry.value)
+	}
+}
+
+// lookup returns element of k, removing it if it's expired.
+func (c *LRU[K, V]) lookup(k K) (*list.Element[*lruEntry[K, V]], bool) {
+	e, ok := c.items[k]
+	if !ok {
+		return nil, false
+	} else if e.Value.expired(time.Now()) {
+		c.evict(e)
+		return nil, false
+	}
+
+	return e, true
+}
+
+// Get returns value of k and marks it as recently used.
+func (c *LRU[K, V]) Get(k K) (v V, ok bool) {
+	e, ok := c.lookup(k)
+	if !ok {
+		return v, false
+	}
+
+	c.ll.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Peek returns value of k without marking it as recently used.
+func (c *LRU[K, V]) Peek(k K) (v V, ok bool) {
+	e, ok := c.lookup(k)
+	if !ok {
+		return v, false
+	}
+
+	return e.Value.value, true
+}
+
+// Delete removes k from cache and reports whether it was present.
+func (c *LRU[K, V]) Delete(k K) bool {
+	e, ok := c.items[k]
+	if ok {
+		c.ll.Remove(e)
+		delete(c.items, k)
+	}
+
+	return ok
+}
+
+// Len returns count of entries in cache. Expired entries, which were not
+// removed yet, are counted too.
+func (c *LRU[K, V]) Len() int { return c.ll.Len() }
+
+// Clear removes all entries from cache.
+func (c *LRU[K, V]) Clear() {
+	c.ll.Init()
+	Clear(c.items)
+}
+
+// SyncLRU is a [LRU] wrapper, which is safe for concurrent use. All methods,
+// including Get, hold exclusive lock, cause reading changes order of entries.
+// OnEvict callback is called under lock too, so it must not use the cache.
+type SyncLRU[K comparable, V any] struct {
+	mu  sync.Mutex
+	lru *LRU[K, V]
+}
+
+// NewSyncLRU creates concurrent-safe LRU cache. See [NewLRU] for details.
+func NewSyncLRU[K comparable, V any](capacity int, onEvict func(K, V)) *SyncLRU[K, V] {
+	lru := NewLRU[K, V](capacity)
+	lru.OnEvict = onEvict
+
+	return &SyncLRU[K, V]{lru: lru}
+}
+
+func (c *SyncLRU[K, V]) Set(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Set(k, v)
+}
+
+func (c *SyncLRU[K, V]) SetWithTTL(k K, v V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.SetWithTTL(k, v, ttl)
+}
+
+func (c *SyncLRU[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Get(k)
+}
+
+func (c *SyncLRU[K, V]) Peek(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Peek(k)
+}
+
+func (c *SyncLRU[K, V]) Delete(k K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Delete(k)
+}
+
+func (c *SyncLRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *SyncLRU[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Clear()
+}
@@ -1,5 +1,13 @@
 package maps
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/slices"
+)
+
 // Keys returns the keys of the map m.
 // The keys will be in an indeterminate order.
 func Keys[M ~map[K]V, K comparable, V any](m M) []K {
@@ -20,6 +28,29 @@ func Values[M ~map[K]V, K comparable, V any](m M) []V {
 	return r
 }
 
+// SortedKeys returns the keys of the map m in ascending order.
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K { return slices.Sort(Keys(m)) }
+
+// SortedKeysFunc returns the keys of the map m, sorted with cmp.
+func SortedKeysFunc[M ~map[K]V, K comparable, V any](m M, cmp func(a, b K) int) []K {
+	return slices.SortFunc(Keys(m), cmp)
+}
+
+// IterSorted returns an iterator over key/value pairs of m in ascending order
+// of keys. It's useful for deterministic output: logs, golden files, etc.
+//
+// Keys are collected and sorted when iteration starts, values are taken from m
+// during iteration.
+func IterSorted[M ~map[K]V, K cmp.Ordered, V any](m M) func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for _, k := range SortedKeys(m) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
+
 // Equal reports whether two maps contain the same key/value pairs.
 // Values are compared using ==.
 func Equal[M1, M2 ~map[K]V, K, V comparable](m1 M1, m2 M2) bool {
@@ -40,6 +71,33 @@ func EqualFunc[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1, V2 any](m1 M1, m2 M
 	return true
 }
 
+// EqualFunc2 is like EqualFunc, but eq also receives key, so values of
+// different keys could be compared differently.
+func EqualFunc2[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1, V2 any](m1 M1, m2 M2, eq func(k K, v1 V1, v2 V2) bool) bool {
+	if len(m1) != len(m2) {
+		return false
+	}
+	for k, v1 := range m1 {
+		if v2, ok := m2[k]; !ok || !eq(k, v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualNested reports whether two maps with slice values contain the same
+// keys, and slices of same keys are equal element by element. Nil and empty
+// slices are considered equal.
+func EqualNested[M1, M2 ~map[K]S, K comparable, S ~[]E, E comparable](m1 M1, m2 M2) bool {
+	return EqualFunc(m1, m2, slices.Equal[S])
+}
+
+// EqualNestedMap reports whether two maps of maps are equal, comparing inner
+// maps with [Equal]. Nil and empty inner maps are considered equal.
+func EqualNestedMap[M1, M2 ~map[K1]IM, IM ~map[K2]V, K1, K2, V comparable](m1 M1, m2 M2) bool {
+	return EqualFunc(m1, m2, Equal[IM, IM])
+}
+
 // Clear removes all entries from m, leaving it empty.
 func Clear[M ~map[K]V, K comparable, V any](m M) {
 	for k := range m {
@@ -84,6 +142,25 @@ func DeleteFunc[M ~map[K]V, K comparable, V any](m M, del func(K, V) bool) M {
 	return m
 }
 
+// Filter returns a new map with key/value pairs of m, for which keep returns
+// true. Unlike [DeleteFunc], m is not modified.
+func Filter[M ~map[K]V, K comparable, V any](m M, keep func(K, V) bool) M {
+	res := make(M)
+	for k, v := range m {
+		if keep(k, v) {
+			res[k] = v
+		}
+	}
+
+	return res
+}
+
+// Reject is the opposite of [Filter]: it returns a new map without key/value
+// pairs, for which reject returns true.
+func Reject[M ~map[K]V, K comparable, V any](m M, reject func(K, V) bool) M {
+	return Filter(m, func(k K, v V) bool { return !reject(k, v) })
+}
+
 // GetOne gets single random key and value from map. If length of map is zero, it
 // returns zero values and `ok` as false.
 //
@@ -113,6 +190,50 @@ func Pop[M ~map[K]V, K comparable, V any](m M) (k K, v V, ok bool) {
 	return k, v, ok
 }
 
+// GetMin returns the smallest key of m and its value. If length of map is
+// zero, it returns zero values and `ok` as false.
+//
+// It takes O(n) time, so for frequent calls consider keeping keys in a heap.
+func GetMin[M ~map[K]V, K cmp.Ordered, V any](m M) (k K, v V, ok bool) {
+	return getExtreme(m, func(a, b K) bool { return a < b })
+}
+
+// GetMax returns the largest key of m and its value. If length of map is
+// zero, it returns zero values and `ok` as false.
+//
+// It takes O(n) time, so for frequent calls consider keeping keys in a heap.
+func GetMax[M ~map[K]V, K cmp.Ordered, V any](m M) (k K, v V, ok bool) {
+	return getExtreme(m, func(a, b K) bool { return a > b })
+}
+
+func getExtreme[M ~map[K]V, K comparable, V any](m M, better func(a, b K) bool) (k K, v V, ok bool) {
+	for key, value := range m {
+		if !ok || better(key, k) {
+			k, v, ok = key, value, true
+		}
+	}
+
+	return k, v, ok
+}
+
+// PopMin is like [Pop], but deterministically removes entry with the smallest
+// key.
+func PopMin[M ~map[K]V, K cmp.Ordered, V any](m M) (k K, v V, ok bool) {
+	if k, v, ok = GetMin(m); ok {
+		delete(m, k)
+	}
+	return k, v, ok
+}
+
+// PopMax is like [Pop], but deterministically removes entry with the largest
+// key.
+func PopMax[M ~map[K]V, K cmp.Ordered, V any](m M) (k K, v V, ok bool) {
+	if k, v, ok = GetMax(m); ok {
+		delete(m, k)
+	}
+	return k, v, ok
+}
+
 // Merge merges map items into base map. If you want to create new map, you can
 // provide nil to base.
 func Merge[M ~map[K]V, K comparable, V any](base M, maps ...M) M {
@@ -128,6 +249,59 @@ func Merge[M ~map[K]V, K comparable, V any](base M, maps ...M) M {
 	return base
 }
 
+// IntersectKeys returns a new map with key/value pairs of a, which keys also
+// exist in b. Values of b are ignored.
+func IntersectKeys[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1, V2 any](a M1, b M2) M1 {
+	res := make(M1)
+	if len(b) < len(a) {
+		for k := range b {
+			if v, ok := a[k]; ok {
+				res[k] = v
+			}
+		}
+		return res
+	}
+
+	for k, v := range a {
+		if _, ok := b[k]; ok {
+			res[k] = v
+		}
+	}
+	return res
+}
+
+// UnionWith returns a new map with keys of both a and b. If key exists in both
+// maps, resulting value is calculated by resolve. If resolve is nil, value from
+// b wins.
+func UnionWith[M ~map[K]V, K comparable, V any](a, b M, resolve func(k K, va, vb V) V) M {
+	res := make(M, len(a)+len(b))
+	for k, v := range a {
+		res[k] = v
+	}
+	for k, vb := range b {
+		if va, ok := res[k]; ok && resolve != nil {
+			vb = resolve(k, va, vb)
+		}
+		res[k] = vb
+	}
+
+	return res
+}
+
+// SubtractKeys returns a new map with key/value pairs of a, except provided
+// keys.
+func SubtractKeys[M ~map[K]V, K comparable, V any](a M, keys ...K) M {
+	res := Clone(a)
+	if res == nil {
+		return make(M)
+	}
+	for _, k := range keys {
+		delete(res, k)
+	}
+
+	return res
+}
+
 // Remap remaps map of one type to different one.
 func Remap[M1 ~map[K1]V1, K1, K2 comparable, V1, V2 any](m M1, f func(K1, V1) (K2, V2)) map[K2]V2 {
 	res := make(map[K2]V2, len(m))
@@ -139,6 +313,49 @@ func Remap[M1 ~map[K1]V1, K1, K2 comparable, V1, V2 any](m M1, f func(K1, V1) (K
 	return res
 }
 
+// RemapErr is like [Remap], but f is able to fail. It stops on the first
+// error and returns it.
+func RemapErr[M1 ~map[K1]V1, K1, K2 comparable, V1, V2 any](m M1, f func(K1, V1) (K2, V2, error)) (map[K2]V2, error) {
+	res := make(map[K2]V2, len(m))
+	for k1, v1 := range m {
+		k2, v2, err := f(k1, v1)
+		if err != nil {
+			return nil, err
+		}
+		res[k2] = v2
+	}
+
+	return res, nil
+}
+
+// RemapValues returns a new map with same keys and values remapped by f.
+func RemapValues[M ~map[K]V1, K comparable, V1, V2 any](m M, f func(V1) V2) map[K]V2 {
+	res := make(map[K]V2, len(m))
+	for k, v := range m {
+		res[k] = f(v)
+	}
+
+	return res
+}
+
+// ErrDuplicateKey is returned by [RemapKeys], if remapped keys collide.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// RemapKeys returns a new map with keys remapped by f and same values. If f
+// returns same key for different keys of m, it returns [ErrDuplicateKey].
+func RemapKeys[M ~map[K1]V, K1, K2 comparable, V any](m M, f func(K1) K2) (map[K2]V, error) {
+	res := make(map[K2]V, len(m))
+	for k1, v := range m {
+		k2 := f(k1)
+		if _, ok := res[k2]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, k2)
+		}
+		res[k2] = v
+	}
+
+	return res, nil
+}
+
 func Contains[M ~map[K]V, K, V comparable](m M, k K, v V) bool {
 	got, ok := m[k]
 	return ok && got == v
@@ -1,10 +1,16 @@
 package maps_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/quenbyako/ext/slices"
 
@@ -177,3 +183,517 @@ func TestDeleteFunc(t *testing.T) {
 		t.Errorf("DeleteFunc result = %v, want %v", mc, want)
 	}
 }
+
+func TestFilter(t *testing.T) {
+	got := Filter(m1, func(k, v int) bool { return k > 3 })
+	want := map[int]int{4: 8, 8: 16}
+	if !Equal(got, want) {
+		t.Errorf("Filter result = %v, want %v", got, want)
+	}
+
+	got = Reject(m1, func(k, v int) bool { return k > 3 })
+	want = map[int]int{1: 2, 2: 4}
+	if !Equal(got, want) {
+		t.Errorf("Reject result = %v, want %v", got, want)
+	}
+
+	if len(m1) != 4 {
+		t.Errorf("Filter modified source map: %v", m1)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	want := []int{1, 2, 4, 8}
+	if got := SortedKeys(m2); !slices.Equal(got, want) {
+		t.Errorf("SortedKeys(%v) = %v, want %v", m2, got, want)
+	}
+
+	want = []int{8, 4, 2, 1}
+	if got := SortedKeysFunc(m2, func(a, b int) int { return b - a }); !slices.Equal(got, want) {
+		t.Errorf("SortedKeysFunc(%v) = %v, want %v", m2, got, want)
+	}
+
+	var got []string
+	IterSorted(m2)(func(k int, v string) bool {
+		got = append(got, v)
+		return k < 4
+	})
+	if want := []string{"2", "4", "8"}; !slices.Equal(got, want) {
+		t.Errorf("IterSorted(%v) yielded %v, want %v", m2, got, want)
+	}
+}
+
+func TestRemapKeys(t *testing.T) {
+	got, err := RemapKeys(m2, func(k int) string { return strconv.Itoa(k * 2) })
+	if want := map[string]string{"2": "2", "4": "4", "8": "8", "16": "16"}; err != nil || !Equal(got, want) {
+		t.Errorf("RemapKeys(%v) = %v, %v, want %v", m2, got, err, want)
+	}
+
+	_, err = RemapKeys(m2, func(k int) bool { return k > 2 })
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("RemapKeys(%v): expected ErrDuplicateKey, got %v", m2, err)
+	}
+
+	if got, want := RemapValues(m1, strconv.Itoa), m2; !Equal(got, want) {
+		t.Errorf("RemapValues(%v) = %v, want %v", m1, got, want)
+	}
+
+	errOdd := errors.New("odd")
+	_, err = RemapErr(m1, func(k, v int) (int, int, error) {
+		if k%2 != 0 {
+			return 0, 0, errOdd
+		}
+		return k, v, nil
+	})
+	if !errors.Is(err, errOdd) {
+		t.Errorf("RemapErr(%v): expected error, got %v", m1, err)
+	}
+}
+
+func TestMultimap(t *testing.T) {
+	mm := GroupBy([]string{"apple", "avocado", "banana", "apricot"}, func(s string) byte { return s[0] })
+
+	if got, want := mm.Get('a'), []string{"apple", "avocado", "apricot"}; !slices.Equal(got, want) {
+		t.Errorf("Get('a') = %v, want %v", got, want)
+	}
+	if got := mm.Len(); got != 4 {
+		t.Errorf("Len() = %v, want 4", got)
+	}
+
+	DeleteValue(mm, 'a', "avocado")
+	if got, want := mm.Get('a'), []string{"apple", "apricot"}; !slices.Equal(got, want) {
+		t.Errorf("Get('a') after DeleteValue = %v, want %v", got, want)
+	}
+
+	DeleteValue(mm, 'b', "banana")
+	if mm.Has('b') || len(mm) != 1 {
+		t.Errorf("key without values must be removed, got %v", mm)
+	}
+
+	mm.Add('c', "cherry")
+	got := mm.Flatten()
+	sort.Strings(got)
+	if want := []string{"apple", "apricot", "cherry"}; !slices.Equal(got, want) {
+		t.Errorf("Flatten() = %v, want %v", got, want)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := Counter[string](slices.Counts([]string{"a", "b", "a", "c", "a", "b"}))
+	c.Merge(Counter[string]{"d": 5, "c": -1})
+
+	if got := c.Total(); got != 10 {
+		t.Errorf("Total() = %v, want 10", got)
+	}
+	if _, ok := c["c"]; ok {
+		t.Errorf("key with zero count must be removed, got %v", c)
+	}
+	if got, want := c.MostCommon(2), []string{"d", "a"}; !slices.Equal(got, want) {
+		t.Errorf("MostCommon(2) = %v, want %v", got, want)
+	}
+	if got := c.MostCommon(-1); len(got) != 3 {
+		t.Errorf("MostCommon(-1) = %v, want all keys", got)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	var evicted []int
+	c := NewLRU[int, string](2)
+	c.OnEvict = func(k int, _ string) { evicted = append(evicted, k) }
+
+	c.Set(1, "one")
+	c.Set(2, "two")
+	c.Get(1) // 2 is the least recently used now
+	c.Set(3, "three")
+
+	if _, ok := c.Peek(2); ok {
+		t.Errorf("2 must be evicted")
+	}
+	if v, ok := c.Get(1); !ok || v != "one" {
+		t.Errorf("Get(1) = %q, %v, want \"one\", true", v, ok)
+	}
+
+	// Peek doesn't change order, so 3 is evicted, not 1
+	c.Peek(3)
+	c.Get(1)
+	c.Set(4, "four")
+	if want := []int{2, 3}; !slices.Equal(evicted, want) {
+		t.Errorf("evicted %v, want %v", evicted, want)
+	}
+
+	c.Delete(1)
+	c.SetWithTTL(5, "five", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok := c.Get(5); ok {
+		t.Errorf("5 must be expired")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %v, want 1", c.Len())
+	}
+
+	// the least recently used entry is evicted, even if other one is expired
+	evicted = nil
+	c = NewLRU[int, string](2)
+	c.OnEvict = func(k int, _ string) { evicted = append(evicted, k) }
+	c.Set(1, "one")
+	c.SetWithTTL(2, "two", time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	c.Set(3, "three")
+	if want := []int{1}; !slices.Equal(evicted, want) {
+		t.Errorf("evicted %v, want %v", evicted, want)
+	}
+
+	s := NewSyncLRU[int, int](0, nil)
+	s.Set(1, 1)
+	if !s.Delete(1) || s.Len() != 0 {
+		t.Errorf("SyncLRU: Delete failed")
+	}
+}
+
+func TestExpiring(t *testing.T) {
+	expired := make(chan string, 10)
+	m := NewExpiring[string, int]()
+	m.OnExpire = func(k string, _ int) { expired <- k }
+
+	m.Set("forever", 1)
+	m.SetWithTTL("short", 2, time.Nanosecond)
+	m.SetWithTTL("janitor", 3, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := m.Get("short"); ok {
+		t.Errorf("short must be expired")
+	}
+	if got := <-expired; got != "short" {
+		t.Errorf("expected short to be expired, got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.StartJanitor(ctx, time.Millisecond)
+
+	select {
+	case got := <-expired:
+		if got != "janitor" {
+			t.Errorf("expected janitor to be expired, got %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("janitor didn't purge expired entry")
+	}
+
+	if v, ok := m.Get("forever"); !ok || v != 1 || m.Len() != 1 {
+		t.Errorf("forever must stay in map")
+	}
+}
+
+func TestSyncMap(t *testing.T) {
+	var m SyncMap[string, int]
+	m.Store("a", 1)
+	if actual, loaded := m.LoadOrStore("a", 2); !loaded || actual != 1 {
+		t.Errorf("LoadOrStore = %v, %v, want 1, true", actual, loaded)
+	}
+	if !m.CompareAndSwap("a", 1, 3) || m.CompareAndSwap("a", 1, 4) {
+		t.Errorf("CompareAndSwap works incorrectly")
+	}
+	m.Store("b", 2)
+
+	if got, want := m.Snapshot(), map[string]int{"a": 3, "b": 2}; !Equal(got, want) {
+		t.Errorf("Snapshot() = %v, want %v", got, want)
+	}
+}
+
+func TestSharded(t *testing.T) {
+	m := NewSharded[int, int](4, func(k int) uint64 { return uint64(k) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Store(i, i*2)
+			m.LoadOrStore(i, 0)
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != 100 {
+		t.Errorf("Len() = %v, want 100", m.Len())
+	}
+	if v, ok := m.Load(10); !ok || v != 20 {
+		t.Errorf("Load(10) = %v, %v, want 20, true", v, ok)
+	}
+
+	m.DeleteAll(1, 2, 3, 1000)
+	m.StoreAll(map[int]int{1: 1, 1000: 1000})
+	if got, want := m.LoadAll(1, 2, 1000), map[int]int{1: 1, 1000: 1000}; !Equal(got, want) {
+		t.Errorf("LoadAll() = %v, want %v", got, want)
+	}
+
+	count := 0
+	m.Range(func(k, v int) bool { count++; return true })
+	if count != m.Len() || count != 99 {
+		t.Errorf("Range visited %v entries, Len() = %v, want 99", count, m.Len())
+	}
+}
+
+func TestPath(t *testing.T) {
+	m := map[string]any{"a": map[string]any{"b": 1, "c": "str"}}
+
+	if v, ok := GetPathAs[int](m, "a", "b"); !ok || v != 1 {
+		t.Errorf("GetPathAs(a.b) = %v, %v, want 1, true", v, ok)
+	}
+	if _, ok := GetPathAs[int](m, "a", "c"); ok {
+		t.Errorf("GetPathAs[int](a.c) must fail")
+	}
+	if _, ok := GetPath(m, "a", "b", "c"); ok {
+		t.Errorf("GetPath(a.b.c) must fail")
+	}
+
+	if err := SetPath(m, true, "x", "y", "z"); err != nil {
+		t.Errorf("SetPath(x.y.z): %v", err)
+	}
+	if v, ok := GetPathAs[bool](m, "x", "y", "z"); !ok || !v {
+		t.Errorf("GetPathAs(x.y.z) = %v, %v, want true, true", v, ok)
+	}
+	if err := SetPath(m, 2, "a", "b", "c"); err == nil {
+		t.Errorf("SetPath(a.b.c) must fail")
+	}
+	if err := SetPath(nil, 1, "a"); err == nil {
+		t.Errorf("SetPath(nil) must fail")
+	}
+	if _, ok := GetPath(nil, "a"); ok {
+		t.Errorf("GetPath(nil, a) must fail")
+	}
+	m["nil"] = map[string]any(nil)
+	if err := SetPath(m, 1, "nil", "a"); err == nil {
+		t.Errorf("SetPath(nil.a) must fail")
+	}
+
+	DeletePath(m, "a", "b")
+	DeletePath(m, "no", "such", "path")
+	if _, ok := GetPath(m, "a", "b"); ok {
+		t.Errorf("a.b must be deleted")
+	}
+}
+
+func TestIter(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	if got := Collect(All(m)); !Equal(got, m) {
+		t.Errorf("Collect(All()) = %v, want %v", got, m)
+	}
+
+	var keys []string
+	KeysSeq(m)(func(k string) bool { keys = append(keys, k); return true })
+	sort.Strings(keys)
+	if got := strings.Join(keys, ""); got != "abc" {
+		t.Errorf("KeysSeq() = %v, want abc", got)
+	}
+
+	sum := 0
+	ValuesSeq(m)(func(v int) bool { sum += v; return sum < 3 })
+	if sum < 3 || sum > 5 {
+		t.Errorf("ValuesSeq() must stop early, sum = %v", sum)
+	}
+}
+
+func TestKeyAlgebra(t *testing.T) {
+	a := map[string]int{"a": 1, "b": 2, "c": 3}
+	b := map[string]bool{"b": true, "c": false, "d": true}
+
+	if got, want := IntersectKeys(a, b), map[string]int{"b": 2, "c": 3}; !Equal(got, want) {
+		t.Errorf("IntersectKeys() = %v, want %v", got, want)
+	}
+
+	sum := func(_ string, x, y int) int { return x + y }
+	if got, want := UnionWith(a, map[string]int{"c": 10, "d": 4}, sum), map[string]int{"a": 1, "b": 2, "c": 13, "d": 4}; !Equal(got, want) {
+		t.Errorf("UnionWith() = %v, want %v", got, want)
+	}
+
+	if got, want := SubtractKeys(a, "a", "z"), map[string]int{"b": 2, "c": 3}; !Equal(got, want) {
+		t.Errorf("SubtractKeys() = %v, want %v", got, want)
+	}
+	if len(a) != 3 {
+		t.Errorf("SubtractKeys() must not modify source map")
+	}
+}
+
+func TestEqualNested(t *testing.T) {
+	a := map[string][]string{"x": {"1", "2"}, "y": nil}
+	b := map[string][]string{"x": {"1", "2"}, "y": {}}
+	if !EqualNested(a, b) {
+		t.Errorf("EqualNested(%v, %v) = false, want true", a, b)
+	}
+	b["x"] = []string{"2", "1"}
+	if EqualNested(a, b) {
+		t.Errorf("EqualNested(%v, %v) = true, want false", a, b)
+	}
+
+	ma := map[string]map[int]bool{"a": {1: true}}
+	mb := map[string]map[int]bool{"a": {1: true}}
+	if !EqualNestedMap(ma, mb) {
+		t.Errorf("EqualNestedMap(%v, %v) = false, want true", ma, mb)
+	}
+
+	c := map[string]float64{"exact": 1, "approx": 1.0001}
+	d := map[string]float64{"exact": 1, "approx": 1}
+	eq := func(k string, x, y float64) bool {
+		if k == "approx" {
+			return math.Abs(x-y) < 0.01
+		}
+		return x == y
+	}
+	if !EqualFunc2(c, d, eq) {
+		t.Errorf("EqualFunc2(%v, %v) = false, want true", c, d)
+	}
+}
+
+func TestPopMinMax(t *testing.T) {
+	m := map[int]string{3: "c", 1: "a", 2: "b", 4: "d"}
+
+	if k, v, ok := GetMin(m); !ok || k != 1 || v != "a" {
+		t.Errorf("GetMin() = %v, %v, %v, want 1, a, true", k, v, ok)
+	}
+	if k, v, ok := GetMax(m); !ok || k != 4 || v != "d" {
+		t.Errorf("GetMax() = %v, %v, %v, want 4, d, true", k, v, ok)
+	}
+
+	var got []int
+	for len(m) > 2 {
+		k, _, _ := PopMin(m)
+		got = append(got, k)
+	}
+	k, _, _ := PopMax(m)
+	got = append(got, k)
+	if want := []int{1, 2, 4}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+
+	if _, _, ok := PopMin(map[int]int{}); ok {
+		t.Errorf("PopMin() on empty map must return false")
+	}
+}
+
+func TestPairs(t *testing.T) {
+	pairs := ToSlice(m2)
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	if len(pairs) != 4 || pairs[0] != (Pair[int, string]{1, "2"}) || pairs[3] != (Pair[int, string]{8, "16"}) {
+		t.Errorf("ToSlice() = %v", pairs)
+	}
+
+	if got := FromSlice(pairs); !Equal(got, m2) {
+		t.Errorf("FromSlice() = %v, want %v", got, m2)
+	}
+
+	got := FromSliceFunc([]string{"a", "bb", "cc"}, func(s string) (int, string) { return len(s), s })
+	if want := map[int]string{1: "a", 2: "cc"}; !Equal(got, want) {
+		t.Errorf("FromSliceFunc() = %v, want %v", got, want)
+	}
+}
+
+func TestStruct(t *testing.T) {
+	type Inner struct {
+		Port int `map:"port"`
+	}
+	type Base struct {
+		Name string `map:"name"`
+	}
+	type Config struct {
+		Base
+		Hosts   []string          `map:"hosts"`
+		Inner   Inner             `map:"inner"`
+		Ptr     *Inner            `map:"ptr,omitempty"`
+		Labels  map[string]string `map:"labels,omitempty"`
+		Ratio   float32
+		Skipped string `map:"-"`
+	}
+
+	src := map[string]any{
+		"name":  "svc",
+		"hosts": []any{"a", "b"},
+		"inner": map[string]any{"port": float64(8080)},
+		"ptr":   map[string]any{"port": 1},
+		"ratio": 0.5,
+	}
+
+	cfg, err := ToStruct[Config](src)
+	if err != nil {
+		t.Fatalf("ToStruct(): %v", err)
+	}
+	if cfg.Name != "svc" || !slices.Equal(cfg.Hosts, []string{"a", "b"}) || cfg.Inner.Port != 8080 ||
+		cfg.Ptr == nil || cfg.Ptr.Port != 1 || cfg.Ratio != 0.5 {
+		t.Errorf("ToStruct() = %+v", cfg)
+	}
+
+	if _, err := ToStruct[Config](map[string]any{"inner": map[string]any{"port": 1.5}}); err == nil {
+		t.Errorf("ToStruct() must fail on lossy number conversion")
+	}
+	type Float64 struct{ V float64 }
+	for _, tt := range []struct {
+		v  any
+		ok bool
+	}{
+		{float64(1<<24 + 1), false}, // float32 has 24-bit mantissa
+		{0.1, false},
+		{1e-50, false},
+		{int64(1<<53 + 1), false}, // float64 has 53-bit mantissa
+		{uint64(1<<63 + 1), false},
+		{int64(-1 << 53), true},
+		{uint64(1 << 63), true},
+	} {
+		var err error
+		if _, isFloat := tt.v.(float64); isFloat {
+			_, err = ToStruct[Config](map[string]any{"ratio": tt.v})
+		} else {
+			_, err = ToStruct[Float64](map[string]any{"v": tt.v})
+		}
+		if (err == nil) != tt.ok {
+			t.Errorf("ToStruct(%T(%v)) error = %v, want error: %v", tt.v, tt.v, err, !tt.ok)
+		}
+	}
+	if _, err := ToStruct[Config](map[string]any{"unknown": 1}, ErrorUnused()); err == nil {
+		t.Errorf("ToStruct() must fail on unknown key with ErrorUnused")
+	}
+
+	got := FromStruct(&Config{Base: Base{Name: "x"}, Inner: Inner{Port: 1}})
+	want := map[string]any{"name": "x", "hosts": []string(nil), "inner": map[string]any{"port": 1}, "Ratio": float32(0)}
+	if !EqualFunc(got, want, func(a, b any) bool { return fmt.Sprint(a) == fmt.Sprint(b) }) {
+		t.Errorf("FromStruct() = %v, want %v", got, want)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// example from RFC 7386, section 3
+	target := map[string]any{
+		"title":   "Goodbye!",
+		"author":  map[string]any{"givenName": "John", "familyName": "Doe"},
+		"tags":    []any{"example", "sample"},
+		"content": "This will be unchanged",
+	}
+	patch := map[string]any{
+		"title":       "Hello!",
+		"phoneNumber": "+01-123-456-7890",
+		"author":      map[string]any{"familyName": nil},
+		"tags":        []any{"example"},
+	}
+	want := map[string]any{
+		"title":       "Hello!",
+		"author":      map[string]any{"givenName": "John"},
+		"tags":        []any{"example"},
+		"content":     "This will be unchanged",
+		"phoneNumber": "+01-123-456-7890",
+	}
+
+	src := MergePatch(nil, target)
+	got := MergePatch(MergePatch(nil, target), patch)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("MergePatch() = %v, want %v", got, want)
+	}
+
+	diff := Diff(src, want)
+	if got := MergePatch(src, diff); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("MergePatch(src, Diff(src, dst)) = %v, want %v", got, want)
+	}
+	if _, ok := diff["content"]; ok {
+		t.Errorf("Diff() must skip unchanged keys, got %v", diff)
+	}
+}
@@ -0,0 +1,96 @@
+package maps
+
+// Multimap is a map, where each key can hold multiple values. It's a named
+// map[K][]V, so conversion from/to plain map is free:
+//
+//	mm := maps.Multimap[string, int](m)
+//	m = map[string][]int(mm)
+//
+// Zero value is nil map, so it must be initialized before Add, e.g. with
+// make(Multimap[K, V]).
+type Multimap[K comparable, V any] map[K][]V
+
+// GroupBy builds a multimap from s, grouping elements by key. Elements in each
+// group keep their order.
+func GroupBy[S ~[]V, K comparable, V any](s S, key func(V) K) Multimap[K, V] {
+	res := make(Multimap[K, V])
+	for _, item := range s {
+		res.Add(key(item), item)
+	}
+
+	return res
+}
+
+// Add appends values to key k.
+func (m Multimap[K, V]) Add(k K, values ...V) {
+	if len(values) > 0 {
+		m[k] = append(m[k], values...)
+	}
+}
+
+// Get returns all values of key k, or nil, if key doesn't exist.
+func (m Multimap[K, V]) Get(k K) []V { return m[k] }
+
+// Has reports whether key k has at least one value.
+func (m Multimap[K, V]) Has(k K) bool { return len(m[k]) > 0 }
+
+// Delete removes key k with all its values.
+func (m Multimap[K, V]) Delete(k K) { delete(m, k) }
+
+// DeleteFunc removes values of key k, for which del returns true. If no values
+// left, key is removed too.
+func (m Multimap[K, V]) DeleteFunc(k K, del func(V) bool) {
+	values, ok := m[k]
+	if !ok {
+		return
+	}
+
+	i := 0
+	for _, v := range values {
+		if !del(v) {
+			values[i] = v
+			i++
+		}
+	}
+
+	if i == 0 {
+		delete(m, k)
+	} else {
+		clear(values[i:])
+		m[k] = values[:i]
+	}
+}
+
+// Keys returns the keys of the multimap. The keys will be in an indeterminate
+// order.
+func (m Multimap[K, V]) Keys() []K { return Keys(m) }
+
+// Len returns total count of values of all keys.
+func (m Multimap[K, V]) Len() (n int) {
+	for _, values := range m {
+		n += len(values)
+	}
+
+	return n
+}
+
+// Flatten returns all values of all keys. Values of each key keep their order,
+// but order of keys is indeterminate.
+func (m Multimap[K, V]) Flatten() []V {
+	res := make([]V, 0, m.Len())
+	for _, values := range m {
+		res = append(res, values...)
+	}
+
+	return res
+}
+
+// Map returns multimap as plain map. It's the same as conversion, and exists
+// for readability in call chains.
+func (m Multimap[K, V]) Map() map[K][]V { return m }
+
+// DeleteValue removes all occurrences of v from values of key k. If no values
+// left, key is removed too.
+func DeleteValue[K, V comparable](m Multimap[K, V], k K, v V) {
+	m.DeleteFunc(k, func(item V) bool { return item == v })
+}
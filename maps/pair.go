@@ -0,0 +1,34 @@
+package maps
+
+import (
+	"github.com/quenbyako/ext/slices"
+)
+
+// Pair is a single key/value entry of a map.
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// ToSlice returns entries of m as a slice of pairs. Order of pairs is not
+// specified, sort them, if you need deterministic output.
+func ToSlice[M ~map[K]V, K comparable, V any](m M) []Pair[K, V] {
+	res := make([]Pair[K, V], 0, len(m))
+	for k, v := range m {
+		res = append(res, Pair[K, V]{Key: k, Value: v})
+	}
+
+	return res
+}
+
+// FromSlice builds a map from pairs. If several pairs have same key, the last
+// one wins.
+func FromSlice[K comparable, V any](pairs []Pair[K, V]) map[K]V {
+	return FromSliceFunc(pairs, func(p Pair[K, V]) (K, V) { return p.Key, p.Value })
+}
+
+// FromSliceFunc builds a map from s, using f to get key and value of each item.
+// If f returns same key for several items, the last one wins.
+func FromSliceFunc[S ~[]E, E any, K comparable, V any](s S, f func(E) (K, V)) map[K]V {
+	return slices.Associate(s, f)
+}
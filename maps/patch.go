@@ -0,0 +1,94 @@
+package maps
+
+import (
+	"reflect"
+)
+
+// MergePatch applies JSON merge patch (RFC 7386) to dst and returns it. If dst
+// is nil, new map is created.
+//
+// Keys with nil values in patch are deleted from dst, nested maps are merged
+// recursively, any other value (including slices) replaces existing one.
+func MergePatch(dst, patch map[string]any) map[string]any {
+	if dst == nil {
+		dst = make(map[string]any, len(patch))
+	}
+
+	for k, v := range patch {
+		switch v := v.(type) {
+		case nil:
+			delete(dst, k)
+		case map[string]any:
+			target, _ := dst[k].(map[string]any)
+			dst[k] = MergePatch(target, v)
+		default:
+			dst[k] = v
+		}
+	}
+
+	return dst
+}
+
+// Diff returns JSON merge patch (RFC 7386), which converts src into dst, so
+// MergePatch(src, Diff(src, dst)) is equal to dst.
+//
+// Merge patch can't represent nil values, so keys with nil values in dst are
+// treated as absent.
+func Diff(src, dst map[string]any) map[string]any {
+	patch := make(map[string]any)
+	for k := range src {
+		if v, ok := dst[k]; !ok || v == nil {
+			patch[k] = nil
+		}
+	}
+
+	for k, v := range dst {
+		if v == nil {
+			continue
+		}
+
+		old, ok := src[k]
+		if !ok {
+			patch[k] = v
+			continue
+		}
+
+		oldMap, oldOk := old.(map[string]any)
+		newMap, newOk := v.(map[string]any)
+		switch {
+		case oldOk && newOk:
+			if nested := Diff(oldMap, newMap); len(nested) > 0 {
+				patch[k] = nested
+			}
+		case !valuesEqual(old, v):
+			patch[k] = v
+		}
+	}
+
+	return patch
+}
+
+// valuesEqual compares decoded JSON-like values without reflection for most
+// common types.
+func valuesEqual(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		return ok && EqualFunc(a, b, valuesEqual)
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !valuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case nil, bool, string, float64, int, int64:
+		return a == b
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,93 @@
+package maps
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetPath returns value stored in nested maps by path of keys. It's useful for
+// decoded JSON or YAML documents, where each level is a map[string]any.
+//
+// Empty path returns m itself.
+func GetPath(m map[string]any, path ...string) (any, bool) {
+	var cur any = m
+	for _, key := range path {
+		next, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = next[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return cur, true
+}
+
+// GetPathAs is like [GetPath], but also asserts value to type T. ok is false
+// if value doesn't exist or has different type.
+func GetPathAs[T any](m map[string]any, path ...string) (v T, ok bool) {
+	raw, ok := GetPath(m, path...)
+	if !ok {
+		return v, false
+	}
+	v, ok = raw.(T)
+	return v, ok
+}
+
+// SetPath sets value in nested maps by path of keys, creating intermediate
+// maps if they don't exist. Returns error, if path is empty, m is nil, or some
+// intermediate value exists, but it's not a non-nil map[string]any.
+func SetPath(m map[string]any, value any, path ...string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	} else if m == nil {
+		return fmt.Errorf("nil map")
+	}
+
+	parent, err := walkPath(m, path[:len(path)-1], true)
+	if err != nil {
+		return err
+	}
+	parent[path[len(path)-1]] = value
+
+	return nil
+}
+
+// DeletePath removes value from nested maps by path of keys. It's a no-op, if
+// path doesn't exist. Empty intermediate maps are kept.
+func DeletePath(m map[string]any, path ...string) {
+	if len(path) == 0 {
+		return
+	}
+
+	if parent, err := walkPath(m, path[:len(path)-1], false); err == nil && parent != nil {
+		delete(parent, path[len(path)-1])
+	}
+}
+
+func walkPath(m map[string]any, path []string, create bool) (map[string]any, error) {
+	cur := m
+	for i, key := range path {
+		raw, ok := cur[key]
+		if !ok {
+			if !create {
+				return nil, nil
+			}
+			next := make(map[string]any)
+			cur[key] = next
+			cur = next
+			continue
+		}
+
+		next, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%v: expected map[string]any, got %T", strings.Join(path[:i+1], "."), raw)
+		} else if next == nil && create {
+			return nil, fmt.Errorf("%v: nil map", strings.Join(path[:i+1], "."))
+		}
+		cur = next
+	}
+
+	return cur, nil
+}
@@ -0,0 +1,170 @@
+package maps
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Sharded is a concurrent map, split into shards, each of them is protected
+// by its own RWMutex. It's designed for write-heavy workloads with high
+// contention, where single mutex becomes a bottleneck, and [SyncMap] degrades.
+type Sharded[K comparable, V any] struct {
+	shards []shard[K, V]
+	hash   func(K) uint64
+	size   atomic.Int64
+}
+
+type shard[K comparable, V any] struct {
+	sync.RWMutex
+	m map[K]V
+}
+
+// NewSharded creates a new sharded map. If shards is not positive, it's set to
+// 4 * GOMAXPROCS. hash must distribute keys uniformly, since key's shard is
+// hash(key) % shards.
+func NewSharded[K comparable, V any](shards int, hash func(K) uint64) *Sharded[K, V] {
+	if hash == nil {
+		panic("hash function is required")
+	} else if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+
+	m := &Sharded[K, V]{shards: make([]shard[K, V], shards), hash: hash}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+
+	return m
+}
+
+func (m *Sharded[K, V]) shardIndex(k K) int     { return int(m.hash(k) % uint64(len(m.shards))) }
+func (m *Sharded[K, V]) shard(k K) *shard[K, V] { return &m.shards[m.shardIndex(k)] }
+
+// Load returns value of k.
+func (m *Sharded[K, V]) Load(k K) (v V, ok bool) {
+	s := m.shard(k)
+	s.RLock()
+	defer s.RUnlock()
+	v, ok = s.m[k]
+	return v, ok
+}
+
+// Store sets value of k.
+func (m *Sharded[K, V]) Store(k K, v V) {
+	s := m.shard(k)
+	s.Lock()
+	defer s.Unlock()
+	m.store(s, k, v)
+}
+
+// must be called under shard lock.
+func (m *Sharded[K, V]) store(s *shard[K, V], k K, v V) {
+	if _, ok := s.m[k]; !ok {
+		m.size.Add(1)
+	}
+	s.m[k] = v
+}
+
+// LoadOrStore returns the existing value for the key if present. Otherwise, it
+// stores and returns the given value. The loaded result is true if the value
+// was loaded, false if stored.
+func (m *Sharded[K, V]) LoadOrStore(k K, v V) (actual V, loaded bool) {
+	s := m.shard(k)
+	s.Lock()
+	defer s.Unlock()
+	if actual, loaded = s.m[k]; loaded {
+		return actual, true
+	}
+	m.store(s, k, v)
+	return v, false
+}
+
+// Delete removes k from map.
+func (m *Sharded[K, V]) Delete(k K) {
+	s := m.shard(k)
+	s.Lock()
+	defer s.Unlock()
+	m.delete(s, k)
+}
+
+// must be called under shard lock.
+func (m *Sharded[K, V]) delete(s *shard[K, V], k K) {
+	if _, ok := s.m[k]; ok {
+		m.size.Add(-1)
+		delete(s.m, k)
+	}
+}
+
+// Len returns count of entries. It doesn't lock any shard, so under concurrent
+// modifications it's an approximation.
+func (m *Sharded[K, V]) Len() int { return int(m.size.Load()) }
+
+// StoreAll sets all key/value pairs of items, locking each shard only once.
+func (m *Sharded[K, V]) StoreAll(items map[K]V) {
+	for i, keys := range m.groupKeys(Keys(items)) {
+		s := &m.shards[i]
+		s.Lock()
+		for _, k := range keys {
+			m.store(s, k, items[k])
+		}
+		s.Unlock()
+	}
+}
+
+// LoadAll returns values of all existing keys, locking each shard only once.
+func (m *Sharded[K, V]) LoadAll(keys ...K) map[K]V {
+	res := make(map[K]V, len(keys))
+	for i, keys := range m.groupKeys(keys) {
+		s := &m.shards[i]
+		s.RLock()
+		for _, k := range keys {
+			if v, ok := s.m[k]; ok {
+				res[k] = v
+			}
+		}
+		s.RUnlock()
+	}
+
+	return res
+}
+
+// DeleteAll removes all keys, locking each shard only once.
+func (m *Sharded[K, V]) DeleteAll(keys ...K) {
+	for i, keys := range m.groupKeys(keys) {
+		s := &m.shards[i]
+		s.Lock()
+		for _, k := range keys {
+			m.delete(s, k)
+		}
+		s.Unlock()
+	}
+}
+
+func (m *Sharded[K, V]) groupKeys(keys []K) map[int][]K {
+	groups := make(map[int][]K)
+	for _, k := range keys {
+		i := m.shardIndex(k)
+		groups[i] = append(groups[i], k)
+	}
+
+	return groups
+}
+
+// Range calls f sequentially for each key and value in the map. If f returns
+// false, range stops the iteration. Shards are locked one by one, so Range
+// doesn't correspond to any consistent snapshot of the map. f must not modify
+// the map, since shard is read-locked during its call.
+func (m *Sharded[K, V]) Range(f func(K, V) bool) {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.RLock()
+		for k, v := range s.m {
+			if !f(k, v) {
+				s.RUnlock()
+				return
+			}
+		}
+		s.RUnlock()
+	}
+}
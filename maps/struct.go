@@ -0,0 +1,369 @@
+package maps
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const defaultStructTag = "map"
+
+// Option configures struct conversion in [ToStruct] and [FromStruct].
+type Option func(*structOptions)
+
+type structOptions struct {
+	tag         string
+	errorUnused bool
+}
+
+// WithTag sets struct tag name, which is used to get field names. Default is
+// "map", e.g. `map:"name,omitempty"`. Fields without tag are named as is.
+func WithTag(name string) Option { return func(o *structOptions) { o.tag = name } }
+
+// ErrorUnused makes [ToStruct] fail, if map contains keys, which are not
+// matched to any field.
+func ErrorUnused() Option { return func(o *structOptions) { o.errorUnused = true } }
+
+func newStructOptions(opts []Option) structOptions {
+	o := structOptions{tag: defaultStructTag}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// ToStruct decodes loosely typed map (e.g. decoded JSON or YAML config) into
+// struct T.
+//
+// Keys are matched to field names exactly first, then case-insensitively.
+// Nested maps are decoded into nested structs, maps and slices are decoded
+// element by element, numbers are converted between numeric types, if it
+// doesn't lose precision. Embedded structs are flattened.
+func ToStruct[T any](m map[string]any, opts ...Option) (T, error) {
+	var res T
+
+	v := reflect.ValueOf(&res).Elem()
+	if v.Kind() != reflect.Struct {
+		return res, fmt.Errorf("expected struct type, got %T", res)
+	}
+
+	if err := decodeStruct(v, m, "", newStructOptions(opts)); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// FromStruct encodes struct (or pointer to struct) into map. Nested structs are
+// encoded as nested maps, fields with omitempty option are skipped, if they
+// have zero value. Structs without exported fields (like time.Time) are stored
+// as is.
+//
+// FromStruct returns nil for nil pointer and panics, if v is not a struct.
+func FromStruct(v any, opts ...Option) map[string]any {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("expected struct, got %T", v))
+	}
+
+	return encodeStruct(rv, newStructOptions(opts))
+}
+
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+func structFields(t reflect.Type, o structOptions) []structField {
+	var res []structField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && isStructType(f.Type) {
+			continue // fields of embedded structs are promoted
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get(o.tag), ",")
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = f.Name
+		}
+
+		res = append(res, structField{
+			name:      name,
+			index:     f.Index,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+
+	return res
+}
+
+func isStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func encodeStruct(v reflect.Value, o structOptions) map[string]any {
+	res := make(map[string]any)
+	for _, f := range structFields(v.Type(), o) {
+		field, err := v.FieldByIndexErr(f.index)
+		if err != nil || f.omitEmpty && field.IsZero() {
+			continue // nil embedded pointer or empty value
+		}
+
+		res[f.name] = encodeValue(field, o)
+	}
+
+	return res
+}
+
+func encodeValue(v reflect.Value, o structOptions) any {
+	switch {
+	case v.Kind() == reflect.Struct && hasExportedFields(v.Type()):
+		return encodeStruct(v, o)
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct && hasExportedFields(v.Elem().Type()):
+		return encodeStruct(v.Elem(), o)
+	default:
+		return v.Interface()
+	}
+}
+
+func decodeStruct(dst reflect.Value, m map[string]any, path string, o structOptions) error {
+	fields := structFields(dst.Type(), o)
+
+	keys := Keys(m)
+	sort.Strings(keys) // deterministic errors
+	for _, key := range keys {
+		f, ok := matchField(fields, key)
+		if !ok {
+			if o.errorUnused {
+				return fmt.Errorf("%v: unknown field", joinPath(path, key))
+			}
+			continue
+		}
+
+		field, err := fieldByIndexAlloc(dst, f.index)
+		if err != nil {
+			return fmt.Errorf("%v: %w", joinPath(path, key), err)
+		}
+		if err := decodeValue(field, m[key], joinPath(path, key), o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex, but allocates nil
+// pointers to embedded structs.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("can't allocate embedded %v", v.Type())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+func matchField(fields []structField, key string) (structField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+
+	return structField{}, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func decodeValue(dst reflect.Value, src any, path string, o structOptions) error {
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := decodeValue(elem.Elem(), src, path, o); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+
+	case reflect.Struct:
+		if m, ok := src.(map[string]any); ok {
+			return decodeStruct(dst, m, path, o)
+		}
+
+	case reflect.Slice:
+		if sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array {
+			res := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+			for i := 0; i < sv.Len(); i++ {
+				if err := decodeValue(res.Index(i), sv.Index(i).Interface(), fmt.Sprintf("%v[%v]", path, i), o); err != nil {
+					return err
+				}
+			}
+			dst.Set(res)
+			return nil
+		}
+
+	case reflect.Map:
+		if sv.Kind() == reflect.Map && sv.Type().Key().AssignableTo(dst.Type().Key()) {
+			res := reflect.MakeMapWithSize(dst.Type(), sv.Len())
+			iter := sv.MapRange()
+			for iter.Next() {
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := decodeValue(elem, iter.Value().Interface(), fmt.Sprintf("%v[%v]", path, iter.Key()), o); err != nil {
+					return err
+				}
+				res.SetMapIndex(iter.Key(), elem)
+			}
+			dst.Set(res)
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if ok := decodeNumber(dst, sv); ok {
+			return nil
+		}
+		return fmt.Errorf("%v: value %v can't be represented as %v", path, src, dst.Type())
+
+	default:
+		if sv.Type().ConvertibleTo(dst.Type()) && sv.Kind() == dst.Kind() {
+			dst.Set(sv.Convert(dst.Type())) // e.g. string to named string type
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%v: can't assign %T to %v", path, src, dst.Type())
+}
+
+// decodeNumber converts numeric value sv to dst, returning false, if sv is not
+// a number, or it can't be represented in dst type without losing precision.
+func decodeNumber(dst, sv reflect.Value) bool {
+	var f float64
+	switch sv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(sv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f = float64(sv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = sv.Float()
+	default:
+		return false
+	}
+
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if f != math.Trunc(f) {
+			return false
+		}
+		var i int64
+		if sv.CanInt() {
+			i = sv.Int()
+		} else if sv.CanUint() && sv.Uint() <= math.MaxInt64 {
+			i = int64(sv.Uint())
+		} else if f >= math.MinInt64 && f < math.MaxInt64 {
+			i = int64(f)
+		} else {
+			return false
+		}
+		if dst.OverflowInt(i) {
+			return false
+		}
+		dst.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if f < 0 || f != math.Trunc(f) {
+			return false
+		}
+		var u uint64
+		if sv.CanUint() {
+			u = sv.Uint()
+		} else if sv.CanInt() {
+			u = uint64(sv.Int())
+		} else if f < math.MaxUint64 {
+			u = uint64(f)
+		} else {
+			return false
+		}
+		if dst.OverflowUint(u) {
+			return false
+		}
+		dst.SetUint(u)
+
+	default:
+		if dst.OverflowFloat(f) || !exactFloat(sv, f) {
+			return false
+		}
+		if dst.Kind() == reflect.Float32 && float64(float32(f)) != f && !math.IsNaN(f) {
+			return false
+		}
+		dst.SetFloat(f)
+	}
+
+	return true
+}
+
+// exactFloat reports whether f, converted from numeric value sv, is equal to
+// it, e.g. integers above 2^53 are rounded in float64.
+func exactFloat(sv reflect.Value, f float64) bool {
+	switch {
+	case sv.CanInt():
+		return f >= math.MinInt64 && f < math.MaxInt64 && int64(f) == sv.Int()
+	case sv.CanUint():
+		return f < math.MaxUint64 && uint64(f) == sv.Uint()
+	default:
+		return true
+	}
+}
@@ -0,0 +1,15 @@
+package maps
+
+import (
+	"github.com/quenbyako/ext/syncmap"
+)
+
+// SyncMap is a typed concurrent map, backed by [sync.Map]. All methods are
+// inherited from [syncmap.Map], including Snapshot, which copies entries into
+// plain map.
+//
+// The zero SyncMap is empty and ready for use. A SyncMap must not be copied
+// after first use.
+type SyncMap[K comparable, V any] struct {
+	syncmap.Map[K, V]
+}
@@ -0,0 +1,303 @@
+package set
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/slices"
+)
+
+// textSeparator separates items in text representation of a set.
+const textSeparator = ","
+
+// MarshalJSONSorted encodes s as JSON array with items in ascending order.
+// Regular MarshalJSON method of sets follows iteration order, which is random
+// for hash-based sets, so use this function, if output must be reproducible.
+func MarshalJSONSorted[T cmp.Ordered](s Set[T]) ([]byte, error) {
+	return json.Marshal(slices.Sort(s.List()))
+}
+
+func decodeJSONItems[T any](data []byte) ([]T, error) {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// marshalText encodes items as sorted comma-separated list. Items must be
+// strings or implement encoding.TextMarshaler.
+func marshalText[T any](items []T) ([]byte, error) {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		var part string
+		switch v := any(item).(type) {
+		case string:
+			part = v
+		case encoding.TextMarshaler:
+			b, err := v.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			part = string(b)
+		default:
+			return nil, fmt.Errorf("set of %T can't be marshaled as text", item)
+		}
+
+		if strings.Contains(part, textSeparator) {
+			return nil, fmt.Errorf("item %q contains separator %q", part, textSeparator)
+		}
+		parts = append(parts, part)
+	}
+
+	sort.Strings(parts)
+
+	return []byte(strings.Join(parts, textSeparator)), nil
+}
+
+// unmarshalText decodes comma-separated list of items. Items must be strings
+// or implement encoding.TextUnmarshaler.
+func unmarshalText[T any](data []byte) ([]T, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	parts := strings.Split(string(data), textSeparator)
+	items := make([]T, len(parts))
+	for i, part := range parts {
+		switch v := any(&items[i]).(type) {
+		case *string:
+			*v = part
+		case encoding.TextUnmarshaler:
+			if err := v.UnmarshalText([]byte(part)); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("set of %T can't be unmarshaled from text", items[i])
+		}
+	}
+
+	return items, nil
+}
+
+var errNotText = errors.New("underlying set doesn't support text encoding")
+
+// MarshalJSON encodes s as JSON array.
+func (s *set[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array.
+func (s *set[T]) UnmarshalJSON(data []byte) error {
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalText encodes s as sorted comma-separated list. Items must be strings
+// or implement encoding.TextMarshaler.
+func (s *set[T]) MarshalText() ([]byte, error) { return marshalText(s.List()) }
+
+// UnmarshalText replaces items of s with items of comma-separated list.
+func (s *set[T]) UnmarshalText(data []byte) error {
+	items, err := unmarshalText[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array.
+func (s *setm[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array.
+func (s *setm[T]) UnmarshalJSON(data []byte) error {
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.lockWrite()
+	defer s.Unlock()
+	s.s.Clear()
+	s.s = s.s.Add(items...)
+
+	return nil
+}
+
+// MarshalText encodes s as sorted comma-separated list, if underlying set
+// supports it.
+func (s *setm[T]) MarshalText() ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if m, ok := s.s.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+
+	return nil, errNotText
+}
+
+// UnmarshalText replaces items of s with items of comma-separated list, if
+// underlying set supports it.
+func (s *setm[T]) UnmarshalText(data []byte) error {
+	s.lockWrite()
+	defer s.Unlock()
+	if u, ok := s.s.(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText(data)
+	}
+
+	return errNotText
+}
+
+// MarshalJSON encodes s as JSON array.
+func (s *setAny[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array.
+func (s *setAny[T]) UnmarshalJSON(data []byte) error {
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array in ascending order.
+func (s *sortedSet[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.items) }
+
+// UnmarshalJSON replaces items of s with items of JSON array. Set must be
+// created with NewSorted or NewSortedFunc.
+func (s *sortedSet[T]) UnmarshalJSON(data []byte) error {
+	if s.cmp == nil {
+		return errors.New("sorted set is not initialized")
+	}
+
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalText encodes s as sorted comma-separated list. Items must be strings
+// or implement encoding.TextMarshaler.
+func (s *sortedSet[T]) MarshalText() ([]byte, error) { return marshalText(s.items) }
+
+// UnmarshalText replaces items of s with items of comma-separated list. Set
+// must be created with NewSorted or NewSortedFunc.
+func (s *sortedSet[T]) UnmarshalText(data []byte) error {
+	if s.cmp == nil {
+		return errors.New("sorted set is not initialized")
+	}
+
+	items, err := unmarshalText[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array.
+func (s *setFunc[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array. Set must be
+// created with NewFunc.
+func (s *setFunc[T]) UnmarshalJSON(data []byte) error {
+	if s.hash == nil || s.eq == nil {
+		return errors.New("set is not initialized")
+	}
+
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array in ascending order.
+func (s Persistent[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array. Set must be
+// created with NewPersistent or NewPersistentFunc.
+func (s *Persistent[T]) UnmarshalJSON(data []byte) error {
+	if s.cmp == nil {
+		return errors.New("persistent set is not initialized")
+	}
+
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	*s = NewPersistentFunc(s.cmp, items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array.
+func (s *setSharded[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.List()) }
+
+// UnmarshalJSON replaces items of s with items of JSON array. Set must be
+// created with NewSharded. Replacement is not atomic: concurrent readers could
+// observe partially decoded set.
+func (s *setSharded[T]) UnmarshalJSON(data []byte) error {
+	if s.hash == nil {
+		return errors.New("sharded set is not initialized")
+	}
+
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
+
+// MarshalJSON encodes s as JSON array.
+func (s *setEq[T]) MarshalJSON() ([]byte, error) { return json.Marshal(s.items) }
+
+// UnmarshalJSON replaces items of s with items of JSON array. Items, which are
+// equal by Eq, are stored once.
+func (s *setEq[T]) UnmarshalJSON(data []byte) error {
+	items, err := decodeJSONItems[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.Clear()
+	s.Add(items...)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/quenbyako/ext/slices"
+)
+
+func TestSet_JSON(t *testing.T) {
+	for name, s := range map[string]Set[int]{
+		"nots":   New(3, 1, 2),
+		"ts":     NewSync(3, 1, 2),
+		"sorted": NewSorted(3, 1, 2),
+		"func":   NewFunc(func(i int) uint64 { return uint64(i) }, func(a, b int) bool { return a == b }, 3, 1, 2),
+	} {
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+
+		u := s.Copy()
+		u.Add(100)
+		if err := json.Unmarshal(data, u); err != nil {
+			t.Fatal(name, err)
+		}
+		if !u.IsEqual(s) {
+			t.Errorf("%v: round trip failed: got %v, want %v", name, u, s)
+		}
+	}
+
+	data, err := MarshalJSONSorted(New(3, 1, 2))
+	if err != nil || string(data) != "[1,2,3]" {
+		t.Errorf("MarshalJSONSorted: got %s, %v", data, err)
+	}
+}
+
+type hashInt int
+
+func (i hashInt) Hash() (uint64, error) { return uint64(i), nil }
+
+// testJSONInterface checks round trip through Set interface, as it happens,
+// when set is a field of a struct.
+func testJSONInterface[T any](t *testing.T, name string, s Set[T], extra T) {
+	t.Helper()
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(name, err)
+	}
+
+	u := s.Copy()
+	u.Add(extra)
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatal(name, err)
+	}
+	if !u.IsEqual(s) {
+		t.Errorf("%v: round trip failed: got %v, want %v", name, u, s)
+	}
+}
+
+func TestSet_JSONInterface(t *testing.T) {
+	for name, s := range map[string]Set[int]{
+		"New":             New(3, 1, 2),
+		"NewSync":         NewSync(3, 1, 2),
+		"NewWithCapacity": NewWithCapacity[int](3).Add(3, 1, 2),
+		"NewSorted":       NewSorted(3, 1, 2),
+		"NewFunc":         NewFunc(func(i int) uint64 { return uint64(i) }, func(a, b int) bool { return a == b }, 3, 1, 2),
+		"NewSharded":      NewSharded(4, intHash, 3, 1, 2),
+	} {
+		testJSONInterface(t, name, s, 100)
+	}
+
+	testJSONInterface(t, "NewAny", NewAny[hashInt](3, 1, 2), 100)
+	testJSONInterface(t, "NewEq", NewEq[caseless]("Go", "rust"), "zig")
+}
+
+func TestPersistent_JSON(t *testing.T) {
+	s := NewPersistent(3, 1, 2)
+	data, err := json.Marshal(s)
+	if err != nil || string(data) != "[1,2,3]" {
+		t.Fatalf("MarshalJSON: got %s, %v", data, err)
+	}
+
+	u := NewPersistent(100)
+	if err := json.Unmarshal(data, &u); err != nil || !slices.Equal(u.List(), []int{1, 2, 3}) {
+		t.Errorf("UnmarshalJSON: got %v, %v", u, err)
+	}
+
+	var zero Persistent[int]
+	if err := json.Unmarshal(data, &zero); err == nil {
+		t.Error("UnmarshalJSON: uninitialized set must fail")
+	}
+}
+
+func TestSet_Text(t *testing.T) {
+	s := NewSync("b", "c", "a")
+
+	data, err := s.(interface{ MarshalText() ([]byte, error) }).MarshalText()
+	if err != nil || string(data) != "a,b,c" {
+		t.Errorf("MarshalText: got %s, %v", data, err)
+	}
+
+	tags := &set[string]{}
+	if err := tags.UnmarshalText([]byte("x,y")); err != nil || !tags.IsEqual(New("x", "y")) {
+		t.Errorf("UnmarshalText: got %v, %v", tags, err)
+	}
+
+	if _, err := New(1, 2).(*set[int]).MarshalText(); err == nil {
+		t.Error("MarshalText: set of ints must not be marshaled as text")
+	}
+	if _, err := New("a,b").(*set[string]).MarshalText(); err == nil {
+		t.Error("MarshalText: items with separator must fail")
+	}
+}
@@ -0,0 +1,189 @@
+package set
+
+// Filter returns a new set with items of s, for which keep returns true. The
+// dynamic type of the returned set is the same as the type of s.
+func Filter[T any](s Set[T], keep func(T) bool) Set[T] {
+	res := s.Copy()
+
+	var drop []T
+	res.Each(func(item T) bool {
+		if !keep(item) {
+			drop = append(drop, item)
+		}
+		return true
+	})
+
+	return res.Remove(drop...)
+}
+
+// Map returns a new non-threadsafe set with results of f applied to each item
+// of s. Size of resulting set could be smaller, if f returns same value for
+// different items.
+func Map[A any, B comparable](s Set[A], f func(A) B) Set[B] {
+	res := &set[B]{make(map[B]struct{}, s.Size())}
+	s.Each(func(item A) bool {
+		res.m[f(item)] = null{}
+		return true
+	})
+
+	return res
+}
+
+// Reduce folds items of s into single value, starting from init. Order of
+// items depends on the set implementation, so f should be commutative.
+func Reduce[T, R any](s Set[T], init R, f func(acc R, item T) R) R {
+	s.Each(func(item T) bool {
+		init = f(init, item)
+		return true
+	})
+
+	return init
+}
+
+// atomicExtractor is implemented by threadsafe sets, which can find and remove
+// matching items atomically.
+type atomicExtractor[T any] interface {
+	popFunc(match func(T) bool) (T, bool)
+	extract(match func(T) bool) Set[T]
+}
+
+// PopFunc removes and returns an item of s, for which match returns true. If
+// there is no such item, ok is false. For threadsafe sets (NewSync and
+// NewSharded) removal is atomic: concurrent calls never return the same item.
+// match is called without holding any lock, so it may use s.
+func PopFunc[T any](s Set[T], match func(T) bool) (item T, ok bool) {
+	if a, isAtomic := s.(atomicExtractor[T]); isAtomic {
+		return a.popFunc(match)
+	}
+
+	s.Each(func(i T) bool {
+		item, ok = i, match(i)
+		return !ok
+	})
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	s.Remove(item)
+
+	return item, true
+}
+
+// Extract removes all items of s, for which match returns true, and returns
+// them as a new set of the same dynamic type as s. For threadsafe sets
+// (NewSync and NewSharded) each item is removed atomically, so concurrent
+// calls never extract the same item twice. match is called without holding
+// any lock, so it may use s.
+func Extract[T any](s Set[T], match func(T) bool) Set[T] {
+	if a, isAtomic := s.(atomicExtractor[T]); isAtomic {
+		return a.extract(match)
+	}
+
+	res := Filter(s, match)
+	s.Remove(res.List()...)
+
+	return res
+}
+
+// emptier is implemented by sets, which can create empty set of the same
+// dynamic type without copying items.
+type emptier[T any] interface{ empty() Set[T] }
+
+// emptyLike returns an empty set of the same dynamic type as s.
+func emptyLike[T any](s Set[T]) Set[T] {
+	if e, ok := s.(emptier[T]); ok {
+		return e.empty()
+	}
+
+	return Filter(s, func(T) bool { return false })
+}
+
+func (s *set[T]) empty() Set[T]       { return &set[T]{make(map[T]struct{})} }
+func (s *setAny[T]) empty() Set[T]    { return &setAny[T]{make(map[uint64]T)} }
+func (s *setEq[T]) empty() Set[T]     { return &setEq[T]{} }
+func (s *sortedSet[T]) empty() Set[T] { return &sortedSet[T]{cmp: s.cmp} }
+
+func (s *setFunc[T]) empty() Set[T] {
+	return &setFunc[T]{m: make(map[uint64][]T), hash: s.hash, eq: s.eq}
+}
+
+func (s *setSharded[T]) empty() Set[T] { return NewSharded(len(s.shards), s.hash) }
+
+func (s *setm[T]) empty() Set[T] {
+	s.RLock()
+	defer s.RUnlock()
+
+	return wrapMutex(emptyLike(s.s))
+}
+
+// popFunc matches items of snapshot without lock, then removes first matched
+// item, which is still in the set.
+func (s *setm[T]) popFunc(match func(T) bool) (item T, ok bool) {
+	for _, item := range s.snapshot() {
+		if !match(item) {
+			continue
+		}
+
+		s.lockWrite()
+		removed := s.s.Has(item)
+		if removed {
+			s.s.Remove(item)
+		}
+		s.Unlock()
+
+		if removed {
+			return item, true
+		}
+	}
+
+	return item, false
+}
+
+func (s *setm[T]) extract(match func(T) bool) Set[T] {
+	var matched []T
+	for _, item := range s.snapshot() {
+		if match(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	s.lockWrite()
+	defer s.Unlock()
+
+	res := emptyLike(s.s)
+	for _, item := range matched {
+		if s.s.Has(item) {
+			s.s.Remove(item)
+			res.Add(item)
+		}
+	}
+
+	return wrapMutex(res)
+}
+
+// popFunc matches items of each shard snapshot without lock, then removes
+// first matched item, which is still in the shard.
+func (s *setSharded[T]) popFunc(match func(T) bool) (item T, ok bool) {
+	found := false
+	s.Each(func(i T) bool {
+		if match(i) && s.remove(i) {
+			item, found = i, true
+		}
+		return !found
+	})
+
+	return item, found
+}
+
+func (s *setSharded[T]) extract(match func(T) bool) Set[T] {
+	res := NewSharded(len(s.shards), s.hash)
+	s.Each(func(item T) bool {
+		if match(item) && s.remove(item) {
+			res.Add(item)
+		}
+		return true
+	})
+
+	return res
+}
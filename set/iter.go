@@ -0,0 +1,25 @@
+package set
+
+// All returns an iterator over items of s, so set could be used in
+// range-over-func loops:
+//
+//	for item := range set.All(s) {
+//		...
+//	}
+//
+// Iteration is done with Each method, so threadsafe sets iterate over a
+// snapshot, and loop body may modify the set.
+func All[T any](s Set[T]) func(yield func(T) bool) {
+	return func(yield func(T) bool) { s.Each(yield) }
+}
+
+// Collect collects items from seq into a new non-threadsafe Set.
+func Collect[T comparable](seq func(yield func(T) bool)) Set[T] {
+	s := &set[T]{make(map[T]struct{})}
+	seq(func(item T) bool {
+		s.m[item] = null{}
+		return true
+	})
+
+	return s
+}
@@ -0,0 +1,178 @@
+package set
+
+// Multiset (also known as bag) is a set, which tracks count of each item.
+// Zero value is not usable, create multisets with NewMultiset.
+type Multiset[T comparable] struct {
+	m    map[T]int
+	size int
+}
+
+// NewMultiset creates a new multiset, each of items is added once, so
+// duplicated items are counted.
+func NewMultiset[T comparable](items ...T) *Multiset[T] {
+	s := &Multiset[T]{m: make(map[T]int, len(items))}
+	for _, item := range items {
+		s.Add(item, 1)
+	}
+
+	return s
+}
+
+// MultisetFromMap creates a new multiset from frequency map. Items with
+// non-positive counts are ignored.
+func MultisetFromMap[M ~map[T]int, T comparable](m M) *Multiset[T] {
+	s := &Multiset[T]{m: make(map[T]int, len(m))}
+	for item, n := range m {
+		s.Add(item, n)
+	}
+
+	return s
+}
+
+// Add adds n copies of item. Non-positive n is ignored.
+func (s *Multiset[T]) Add(item T, n int) {
+	if n <= 0 {
+		return
+	}
+	s.m[item] += n
+	s.size += n
+}
+
+// Remove removes up to n copies of item and returns count of actually removed
+// ones.
+func (s *Multiset[T]) Remove(item T, n int) int {
+	count := s.m[item]
+	if n <= 0 || count == 0 {
+		return 0
+	}
+
+	if n >= count {
+		n = count
+		delete(s.m, item)
+	} else {
+		s.m[item] = count - n
+	}
+	s.size -= n
+
+	return n
+}
+
+// Count returns number of copies of item.
+func (s *Multiset[T]) Count(item T) int { return s.m[item] }
+
+// Has reports whether all items exist at least once. It returns false if
+// nothing is passed.
+func (s *Multiset[T]) Has(items ...T) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if s.m[item] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns total number of items, including copies.
+func (s *Multiset[T]) Size() int { return s.size }
+
+// Distinct returns number of unique items.
+func (s *Multiset[T]) Distinct() int { return len(s.m) }
+
+// IsEmpty reports whether the multiset is empty.
+func (s *Multiset[T]) IsEmpty() bool { return s.size == 0 }
+
+// Clear removes all items.
+func (s *Multiset[T]) Clear() { s.m, s.size = make(map[T]int), 0 }
+
+// Each traverses unique items with their counts. Traversal will continue
+// until all items have been visited, or if the closure returns false.
+func (s *Multiset[T]) Each(f func(item T, count int) bool) bool {
+	for item, count := range s.m {
+		if !f(item, count) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns a new Multiset with a copy of s.
+func (s *Multiset[T]) Copy() *Multiset[T] { return MultisetFromMap(s.m) }
+
+// IsEqual reports whether both multisets have same items with same counts.
+func (s *Multiset[T]) IsEqual(t *Multiset[T]) bool {
+	if s.size != t.size || len(s.m) != len(t.m) {
+		return false
+	}
+
+	for item, n := range s.m {
+		if t.m[item] != n {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new multiset, where count of each item is the maximum of
+// its counts in s and t.
+func (s *Multiset[T]) Union(t *Multiset[T]) *Multiset[T] {
+	res := s.Copy()
+	for item, n := range t.m {
+		if diff := n - res.m[item]; diff > 0 {
+			res.Add(item, diff)
+		}
+	}
+
+	return res
+}
+
+// Intersection returns a new multiset, where count of each item is the
+// minimum of its counts in s and t.
+func (s *Multiset[T]) Intersection(t *Multiset[T]) *Multiset[T] {
+	res := NewMultiset[T]()
+	for item, n := range s.m {
+		res.Add(item, min(n, t.m[item]))
+	}
+
+	return res
+}
+
+// Sum returns a new multiset, where count of each item is the sum of its
+// counts in s and t.
+func (s *Multiset[T]) Sum(t *Multiset[T]) *Multiset[T] {
+	res := s.Copy()
+	for item, n := range t.m {
+		res.Add(item, n)
+	}
+
+	return res
+}
+
+// Map returns frequency map of items. Returned map is a copy, so it's safe to
+// modify it.
+func (s *Multiset[T]) Map() map[T]int {
+	res := make(map[T]int, len(s.m))
+	for item, n := range s.m {
+		res[item] = n
+	}
+
+	return res
+}
+
+// Set returns a new non-threadsafe Set with unique items of s.
+func (s *Multiset[T]) Set() Set[T] { return FromKeys(s.m) }
+
+// List returns all items, each of them is repeated according to its count.
+func (s *Multiset[T]) List() []T {
+	list := make([]T, 0, s.size)
+	for item, n := range s.m {
+		for i := 0; i < n; i++ {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
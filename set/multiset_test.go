@@ -0,0 +1,53 @@
+package set
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMultiset(t *testing.T) {
+	s := NewMultiset("a", "b", "a", "c", "a")
+
+	if s.Size() != 5 || s.Distinct() != 3 || s.Count("a") != 3 {
+		t.Error("NewMultiset: unexpected counts, got", s.Map())
+	}
+
+	if n := s.Remove("a", 2); n != 2 || s.Count("a") != 1 {
+		t.Error("Remove: expected 2 removed and 1 left, got", n, s.Count("a"))
+	}
+	if n := s.Remove("b", 10); n != 1 || s.Has("b") {
+		t.Error("Remove: expected all copies removed, got", n, s.Count("b"))
+	}
+	if s.Size() != 2 {
+		t.Error("Remove: expected size 2, got", s.Size())
+	}
+}
+
+func TestMultiset_Operations(t *testing.T) {
+	a := MultisetFromMap(map[string]int{"x": 3, "y": 1})
+	b := MultisetFromMap(map[string]int{"x": 1, "y": 2, "z": 1, "skip": 0})
+
+	for _, tt := range []struct {
+		name string
+		got  *Multiset[string]
+		want map[string]int
+	}{
+		{"Union", a.Union(b), map[string]int{"x": 3, "y": 2, "z": 1}},
+		{"Intersection", a.Intersection(b), map[string]int{"x": 1, "y": 1}},
+		{"Sum", a.Sum(b), map[string]int{"x": 4, "y": 3, "z": 1}},
+	} {
+		if got := tt.got.Map(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.name, got, tt.want)
+		}
+		if !tt.got.IsEqual(MultisetFromMap(tt.want)) {
+			t.Errorf("%v: IsEqual must be true for same counts", tt.name)
+		}
+	}
+
+	if a.Count("x") != 3 || b.Count("x") != 1 {
+		t.Error("operations must not modify operands")
+	}
+	if !a.Set().IsEqual(New("x", "y")) {
+		t.Error("Set: unexpected items, got", a.Set())
+	}
+}
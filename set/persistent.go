@@ -0,0 +1,227 @@
+package set
+
+import (
+	"github.com/quenbyako/ext/cmp"
+)
+
+// Persistent is an immutable sorted set. Add and Remove don't modify the
+// receiver, instead they return a new set, which shares unchanged parts of
+// the tree with the original one, so snapshotting a set is free, and each
+// modification allocates only O(log n) nodes.
+//
+// Zero value is not usable, create sets with NewPersistent or
+// NewPersistentFunc. Persistent is safe for concurrent use, since it's never
+// modified.
+type Persistent[T any] struct {
+	root *pnode[T]
+	cmp  func(a, b T) int
+}
+
+// pnode is a node of persistent AVL tree. Nodes are never modified after
+// creation.
+type pnode[T any] struct {
+	item        T
+	left, right *pnode[T]
+	height      int
+	size        int
+}
+
+// NewPersistent creates a new persistent set with items.
+func NewPersistent[T cmp.Ordered](items ...T) Persistent[T] {
+	return NewPersistentFunc(cmp.Compare[T], items...)
+}
+
+// NewPersistentFunc is like NewPersistent, but items are ordered with cmp
+// function.
+func NewPersistentFunc[T any](cmp func(a, b T) int, items ...T) Persistent[T] {
+	return Persistent[T]{cmp: cmp}.Add(items...)
+}
+
+// Add returns a new set with items added.
+func (s Persistent[T]) Add(items ...T) Persistent[T] {
+	for _, item := range items {
+		s.root = s.insert(s.root, item)
+	}
+
+	return s
+}
+
+// Remove returns a new set without items.
+func (s Persistent[T]) Remove(items ...T) Persistent[T] {
+	for _, item := range items {
+		s.root = s.remove(s.root, item)
+	}
+
+	return s
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of  the items exist.
+func (s Persistent[T]) Has(items ...T) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if !s.has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Persistent[T]) has(item T) bool {
+	for n := s.root; n != nil; {
+		switch c := s.cmp(item, n.item); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Size returns the number of items in a set.
+func (s Persistent[T]) Size() int { return s.root.getSize() }
+
+// IsEmpty reports whether the set is empty.
+func (s Persistent[T]) IsEmpty() bool { return s.root == nil }
+
+// Each traverses the items in ascending order, calling the provided function
+// for each set member. Traversal will continue until all items in the Set have
+// been visited, or if the closure returns false.
+func (s Persistent[T]) Each(f func(item T) bool) bool { return s.root.each(f) }
+
+// List returns a slice of all items in ascending order.
+func (s Persistent[T]) List() []T {
+	list := make([]T, 0, s.Size())
+	s.Each(func(item T) bool {
+		list = append(list, item)
+		return true
+	})
+
+	return list
+}
+
+// Mutable returns a regular non-threadsafe Set with items of s.
+func (s Persistent[T]) Mutable() SortedSet[T] {
+	return &sortedSet[T]{items: s.List(), cmp: s.cmp}
+}
+
+// String returns a string representation of s
+func (s Persistent[T]) String() string { return stringList(s.List()) }
+
+func (n *pnode[T]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *pnode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *pnode[T]) each(f func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.each(f) && f(n.item) && n.right.each(f)
+}
+
+func newPnode[T any](item T, left, right *pnode[T]) *pnode[T] {
+	return &pnode[T]{
+		item:   item,
+		left:   left,
+		right:  right,
+		height: max(left.getHeight(), right.getHeight()) + 1,
+		size:   left.getSize() + right.getSize() + 1,
+	}
+}
+
+// balance creates a new node with AVL invariant restored. Children must be
+// already balanced, and their heights differ at most by 2.
+func balance[T any](item T, l, r *pnode[T]) *pnode[T] {
+	switch hl, hr := l.getHeight(), r.getHeight(); {
+	case hl > hr+1:
+		if l.left.getHeight() >= l.right.getHeight() {
+			return newPnode(l.item, l.left, newPnode(item, l.right, r))
+		}
+		lr := l.right
+		return newPnode(lr.item, newPnode(l.item, l.left, lr.left), newPnode(item, lr.right, r))
+
+	case hr > hl+1:
+		if r.right.getHeight() >= r.left.getHeight() {
+			return newPnode(r.item, newPnode(item, l, r.left), r.right)
+		}
+		rl := r.left
+		return newPnode(rl.item, newPnode(item, l, rl.left), newPnode(r.item, rl.right, r.right))
+
+	default:
+		return newPnode(item, l, r)
+	}
+}
+
+func (s Persistent[T]) insert(n *pnode[T], item T) *pnode[T] {
+	if n == nil {
+		return newPnode(item, nil, nil)
+	}
+
+	switch c := s.cmp(item, n.item); {
+	case c < 0:
+		if left := s.insert(n.left, item); left != n.left {
+			return balance(n.item, left, n.right)
+		}
+	case c > 0:
+		if right := s.insert(n.right, item); right != n.right {
+			return balance(n.item, n.left, right)
+		}
+	}
+
+	return n // already exists, nothing is copied
+}
+
+func (s Persistent[T]) remove(n *pnode[T], item T) *pnode[T] {
+	if n == nil {
+		return nil
+	}
+
+	switch c := s.cmp(item, n.item); {
+	case c < 0:
+		if left := s.remove(n.left, item); left != n.left {
+			return balance(n.item, left, n.right)
+		}
+		return n
+	case c > 0:
+		if right := s.remove(n.right, item); right != n.right {
+			return balance(n.item, n.left, right)
+		}
+		return n
+	}
+
+	switch {
+	case n.left == nil:
+		return n.right
+	case n.right == nil:
+		return n.left
+	default:
+		right, successor := removeMin(n.right)
+		return balance(successor, n.left, right)
+	}
+}
+
+func removeMin[T any](n *pnode[T]) (*pnode[T], T) {
+	if n.left == nil {
+		return n.right, n.item
+	}
+
+	left, item := removeMin(n.left)
+	return balance(n.item, left, n.right), item
+}
@@ -0,0 +1,78 @@
+package set
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func checkAVL[T any](t *testing.T, n *pnode[T]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+
+	hl, hr := checkAVL(t, n.left), checkAVL(t, n.right)
+	if hl-hr > 1 || hr-hl > 1 {
+		t.Fatalf("tree is unbalanced: left height %v, right height %v", hl, hr)
+	}
+	if n.size != n.left.getSize()+n.right.getSize()+1 {
+		t.Fatalf("invalid size of node %v", n.item)
+	}
+
+	return max(hl, hr) + 1
+}
+
+func TestPersistent(t *testing.T) {
+	s0 := NewPersistent(3, 1, 2)
+	s1 := s0.Add(5, 4)
+	s2 := s1.Remove(1, 3, 100)
+
+	if got := s0.List(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Error("Add: original set must not change, got", got)
+	}
+	if got := s1.List(); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Error("Add: unexpected items, got", got)
+	}
+	if got := s2.List(); !reflect.DeepEqual(got, []int{2, 4, 5}) {
+		t.Error("Remove: unexpected items, got", got)
+	}
+	if !s1.Has(1, 5) || s2.Has(1) || s2.Has() {
+		t.Error("Has: unexpected membership")
+	}
+	if s := s1.Add(1); s.root != s1.root {
+		t.Error("Add: adding existing item must not copy tree")
+	}
+	if got := s2.String(); got != "set[2, 4, 5]" {
+		t.Error("String: unexpected output, got", got)
+	}
+}
+
+func TestPersistent_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	s := NewPersistent[int]()
+	ref := make(map[int]struct{})
+
+	for i := 0; i < 5000; i++ {
+		v := r.Intn(500)
+		if r.Intn(3) == 0 {
+			s = s.Remove(v)
+			delete(ref, v)
+		} else {
+			s = s.Add(v)
+			ref[v] = struct{}{}
+		}
+	}
+
+	checkAVL(t, s.root)
+
+	want := make([]int, 0, len(ref))
+	for v := range ref {
+		want = append(want, v)
+	}
+	sort.Ints(want)
+	if got := s.List(); !reflect.DeepEqual(got, want) || s.Size() != len(want) {
+		t.Errorf("unexpected items: got %v items, want %v", s.Size(), len(want))
+	}
+}
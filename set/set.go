@@ -7,6 +7,7 @@ package set
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -39,17 +40,63 @@ type Set[T any] interface {
 	// with the given t set.
 	Merge(s Set[T]) Set[T]
 	Separate(s Set[T]) Set[T]
+
+	// Union adds all items of t to the set. The underlying Set is modified.
+	// It's the same as Merge.
+	Union(t Set[T]) Set[T]
+	// Intersect removes all items, which don't exist in t. The underlying Set
+	// is modified.
+	Intersect(t Set[T]) Set[T]
+	// Difference removes all items, which exist in t. The underlying Set is
+	// modified. It's the same as Separate.
+	Difference(t Set[T]) Set[T]
+	// SymmetricDifference keeps only items, which exist either in the set or
+	// in t, but not in both. The underlying Set is modified.
+	SymmetricDifference(t Set[T]) Set[T]
+
+	// UnionCopy is like Union, but returns a new set, keeping the set intact.
+	UnionCopy(t Set[T]) Set[T]
+	// IntersectCopy is like Intersect, but returns a new set, keeping the set
+	// intact.
+	IntersectCopy(t Set[T]) Set[T]
+	// DifferenceCopy is like Difference, but returns a new set, keeping the set
+	// intact.
+	DifferenceCopy(t Set[T]) Set[T]
+	// SymmetricDifferenceCopy is like SymmetricDifference, but returns a new
+	// set, keeping the set intact.
+	SymmetricDifferenceCopy(t Set[T]) Set[T]
 }
 
 // helpful to not write everywhere struct{}{}
 type null = struct{}
 
-// New creates and initalizes a new Set interface. Its single parameter
-// denotes the type of set to create. Either ThreadSafe or
-// NonThreadSafe. The default is ThreadSafe.
-func New[T comparable](items ...T) Set[T]  { return newNonTS(items...) }
+// New creates and initalizes a new non-threadsafe Set, populated with items.
+func New[T comparable](items ...T) Set[T] { return newNonTS(items...) }
+
+// NewAny is like New, but creates set of items, which are identified by their
+// Hash() method.
 func NewAny[T Hashable](items ...T) Set[T] { return newAnyNonTS(items...) }
 
+// NewSync creates and initializes a new threadsafe Set, populated with items.
+func NewSync[T comparable](items ...T) Set[T] { return wrapMutex(newNonTS(items...)) }
+
+// NewWithCapacity creates a new non-threadsafe Set with space preallocated for
+// n items.
+func NewWithCapacity[T comparable](n int) Set[T] { return &set[T]{make(map[T]struct{}, n)} }
+
+// FromSlice creates a new non-threadsafe Set with items of s.
+func FromSlice[S ~[]T, T comparable](s S) Set[T] { return AddSlice(NewWithCapacity[T](len(s)), s) }
+
+// FromKeys creates a new non-threadsafe Set with keys of m.
+func FromKeys[M ~map[K]V, K comparable, V any](m M) Set[K] {
+	s := &set[K]{make(map[K]struct{}, len(m))}
+	for k := range m {
+		s.m[k] = null{}
+	}
+
+	return s
+}
+
 // Union is the merger of multiple sets. It returns a new set with all the
 // elements present in all the sets that are passed.
 //
@@ -111,13 +158,157 @@ func SymmetricDifference[T any](s, t Set[T]) Set[T] {
 	return Union(u, v)
 }
 
+// FromValues creates a new non-threadsafe Set with unique values of m.
+func FromValues[M ~map[K]V, K, V comparable](m M) Set[V] {
+	s := &set[V]{make(map[V]struct{}, len(m))}
+	for _, v := range m {
+		s.m[v] = null{}
+	}
+
+	return s
+}
+
+// ToMap returns items of s as keys of a new map, so it could be used with maps
+// package helpers.
+func ToMap[T comparable](s Set[T]) map[T]struct{} {
+	m := make(map[T]struct{}, s.Size())
+	s.Each(func(item T) bool {
+		m[item] = null{}
+		return true
+	})
+
+	return m
+}
+
+// Compare splits items of a and b into three new sets: items, which exist only
+// in a, only in b, and in both sets. Each set is iterated once.
+//
+// The dynamic type of onlyA and both is determined by a, onlyB — by b.
+func Compare[T any](a, b Set[T]) (onlyA, onlyB, both Set[T]) {
+	onlyA, onlyB, both = emptyLike(a), emptyLike(b), emptyLike(a)
+
+	a.Each(func(item T) bool {
+		if b.Has(item) {
+			both.Add(item)
+		} else {
+			onlyA.Add(item)
+		}
+		return true
+	})
+	b.Each(func(item T) bool {
+		if !a.Has(item) {
+			onlyB.Add(item)
+		}
+		return true
+	})
+
+	return onlyA, onlyB, both
+}
+
+// AddSlice adds all items to s. Unlike s.Add(items...), it grows underlying
+// storage of non-threadsafe and threadsafe sets once, so adding large number of
+// items doesn't cause repeated rehashing.
+func AddSlice[S ~[]T, T comparable](s Set[T], items S) Set[T] {
+	switch s := s.(type) {
+	case *set[T]:
+		if len(items) > len(s.m) {
+			// map can't be grown in place, so it's cheaper to rebuild it once
+			m := make(map[T]struct{}, len(s.m)+len(items))
+			for item := range s.m {
+				m[item] = null{}
+			}
+			s.m = m
+		}
+		for _, item := range items {
+			s.m[item] = null{}
+		}
+		return s
+
+	case *setm[T]:
+		s.lockWrite()
+		defer s.Unlock()
+		s.s = AddSlice(s.s, items)
+		return s
+
+	default:
+		return s.Add(items...)
+	}
+}
+
+// RemoveSlice removes all items from s. Unlike s.Remove(items...), it deletes
+// items of non-threadsafe set directly, and for threadsafe set the lock is
+// taken once for all items.
+func RemoveSlice[S ~[]T, T comparable](s Set[T], items S) Set[T] {
+	switch s := s.(type) {
+	case *set[T]:
+		for _, item := range items {
+			delete(s.m, item)
+		}
+		return s
+
+	case *setm[T]:
+		s.lockWrite()
+		defer s.Unlock()
+		s.s = RemoveSlice(s.s, items)
+		return s
+
+	default:
+		return s.Remove(items...)
+	}
+}
+
+// IsProperSubset reports whether a is a proper subset of b: all items of a
+// exist in b, and b has at least one item, which doesn't exist in a.
+func IsProperSubset[T any](a, b Set[T]) bool { return a.Size() < b.Size() && b.IsSubset(a) }
+
+// IsProperSuperset reports whether a is a proper superset of b: all items of b
+// exist in a, and a has at least one item, which doesn't exist in b.
+func IsProperSuperset[T any](a, b Set[T]) bool { return IsProperSubset(b, a) }
+
+// IsDisjoint reports whether a and b have no items in common. It iterates the
+// smaller set and stops at the first common item.
+func IsDisjoint[T any](a, b Set[T]) bool {
+	if a.Size() > b.Size() {
+		a, b = b, a
+	}
+
+	return a.Each(func(item T) bool { return !b.Has(item) })
+}
+
+// ListSorted returns a slice of all items of s, sorted with less function.
+func ListSorted[T any](s Set[T], less func(a, b T) bool) []T {
+	list := s.List()
+	sort.Slice(list, func(i, j int) bool { return less(list[i], list[j]) })
+
+	return list
+}
+
+// StringSorted is like String method of the set, but items are ordered with
+// less function instead of their string representation.
+func StringSorted[T any](s Set[T], less func(a, b T) bool) string {
+	return stringList(ListSorted(s, less))
+}
+
+// stringSet returns string representation of unordered set. Items are sorted
+// by their string representation, so output is stable, independently of
+// iteration order.
 func stringSet[T any](s Set[T]) string {
-	l := s.List()
-	t := make([]string, 0, len(l))
-	for _, item := range l {
+	t := formatItems(s.List())
+	sort.Strings(t)
+
+	return fmt.Sprintf("set[%s]", strings.Join(t, ", "))
+}
 
+// stringList returns string representation of set, keeping items order.
+func stringList[T any](items []T) string {
+	return fmt.Sprintf("set[%s]", strings.Join(formatItems(items), ", "))
+}
+
+func formatItems[T any](items []T) []string {
+	t := make([]string, 0, len(items))
+	for _, item := range items {
 		t = append(t, fmt.Sprintf("%v", item))
 	}
 
-	return fmt.Sprintf("set[%s]", strings.Join(t, ", "))
+	return t
 }
@@ -0,0 +1,166 @@
+package set
+
+import (
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/slices"
+)
+
+// setEq is backed by plain slice and uses Eq method of items to check
+// membership, so all lookups are O(n). It's intended for small sets of types
+// with custom equality, which can't be hashed.
+type setEq[T cmp.Eq[T]] struct {
+	items []T
+}
+
+// NewEq creates and initializes a new non-threadsafe Set, which compares items
+// with their Eq method.
+func NewEq[T cmp.Eq[T]](items ...T) Set[T] { return (&setEq[T]{}).Add(items...) }
+
+func (s *setEq[T]) index(item T) int { return slices.IndexEq(s.items, item) }
+func (s *setEq[T]) has(item T) bool  { return s.index(item) >= 0 }
+
+// Add includes the specified items (one or more) to the set. The underlying
+// Set s is modified. If passed nothing it silently returns.
+func (s *setEq[T]) Add(items ...T) Set[T] {
+	for _, item := range items {
+		if !s.has(item) {
+			s.items = append(s.items, item)
+		}
+	}
+
+	return s
+}
+
+// Remove deletes the specified items from the set.  The underlying Set s is
+// modified. If passed nothing it silently returns.
+func (s *setEq[T]) Remove(items ...T) Set[T] {
+	for _, item := range items {
+		if i := s.index(item); i >= 0 {
+			s.removeAt(i)
+		}
+	}
+
+	return s
+}
+
+// removeAt deletes i-th item, moving the last one in its place.
+func (s *setEq[T]) removeAt(i int) {
+	last := len(s.items) - 1
+	s.items[i] = s.items[last]
+	var zero T
+	s.items[last] = zero
+	s.items = s.items[:last]
+}
+
+// Pop  deletes and return an item from the set. The underlying Set s is
+// modified. If set is empty, nil is returned.
+func (s *setEq[T]) Pop() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+
+	item = s.items[len(s.items)-1]
+	s.removeAt(len(s.items) - 1)
+
+	return item, true
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of  the items exist.
+func (s *setEq[T]) Has(items ...T) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if !s.has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *setEq[T]) Size() int     { return len(s.items) }
+func (s *setEq[T]) Clear()        { s.items = nil }
+func (s *setEq[T]) IsEmpty() bool { return s.Size() == 0 }
+func (s *setEq[T]) IsEqual(t Set[T]) bool {
+	// Force locking only if given set is threadsafe.
+	if conv, ok := t.(rwLocker); ok {
+		conv.RLock()
+		defer conv.RUnlock()
+	}
+
+	if len(s.items) != t.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSubset tests whether t is a subset of s.
+func (s *setEq[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSuperset tests whether t is a superset of s.
+func (s *setEq[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
+
+// Each traverses the items in the Set, calling the provided function for each
+// set member. Traversal will continue until all items in the Set have been
+// visited, or if the closure returns false.
+func (s *setEq[T]) Each(f func(item T) bool) bool {
+	for _, item := range s.items {
+		if !f(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns a new Set with a copy of s.
+func (s *setEq[T]) Copy() Set[T] { return &setEq[T]{items: slices.Clone(s.items)} }
+
+// String returns a string representation of s
+func (s *setEq[T]) String() string { return stringSet[T](s) }
+
+// List returns a slice of all items.
+func (s *setEq[T]) List() []T { return slices.Clone(s.items) }
+
+// Merge is like Union, however it modifies the current set it's applied on
+// with the given t set.
+func (s *setEq[T]) Merge(t Set[T]) Set[T] { return s.Add(t.List()...) }
+
+// it's not the opposite of Merge.
+// Separate removes the set items containing in t from set s. Please aware that
+func (s *setEq[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *setEq[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *setEq[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *setEq[T]) Intersect(t Set[T]) Set[T] {
+	s.items = slices.DeleteFunc(s.items, func(item T) bool { return !t.Has(item) })
+
+	return s
+}
+
+func (s *setEq[T]) SymmetricDifference(t Set[T]) Set[T] {
+	for _, item := range t.List() {
+		if i := s.index(item); i >= 0 {
+			s.removeAt(i)
+		} else {
+			s.items = append(s.items, item)
+		}
+	}
+
+	return s
+}
+
+func (s *setEq[T]) UnionCopy(t Set[T]) Set[T]               { return s.Copy().Union(t) }
+func (s *setEq[T]) IntersectCopy(t Set[T]) Set[T]           { return s.Copy().Intersect(t) }
+func (s *setEq[T]) DifferenceCopy(t Set[T]) Set[T]          { return s.Copy().Difference(t) }
+func (s *setEq[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] { return s.Copy().SymmetricDifference(t) }
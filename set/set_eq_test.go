@@ -0,0 +1,28 @@
+package set
+
+import (
+	"strings"
+	"testing"
+)
+
+// caseless is a string, which ignores case in comparison.
+type caseless string
+
+func (c caseless) Eq(other caseless) bool { return strings.EqualFold(string(c), string(other)) }
+
+func TestSetEq(t *testing.T) {
+	s := NewEq[caseless]("Go", "GO", "rust", "Rust")
+
+	if s.Size() != 2 || !s.Has("go", "RUST") {
+		t.Error("NewEq: items must be compared with Eq, got", s)
+	}
+
+	s.Remove("RUST")
+	if s.Size() != 1 || s.Has("rust") {
+		t.Error("Remove: item must be removed by Eq, got", s)
+	}
+
+	if !s.IsEqual(NewEq[caseless]("gO")) {
+		t.Error("IsEqual: sets must be equal, got", s)
+	}
+}
@@ -0,0 +1,218 @@
+package set
+
+// setFunc stores items of any type, using custom hash and equality functions.
+// Items with colliding hashes are kept in same bucket and compared with eq.
+type setFunc[T any] struct {
+	m    map[uint64][]T
+	size int
+	hash func(T) uint64
+	eq   func(a, b T) bool
+}
+
+var _ Set[[]int] = (*setFunc[[]int])(nil)
+
+// NewFunc creates and initializes a new non-threadsafe Set of non-comparable
+// items (slices, spans, large structs, etc.). Equal items must have equal
+// hashes, but hash collisions are allowed: they are resolved by eq.
+func NewFunc[T any](hash func(T) uint64, eq func(a, b T) bool, items ...T) Set[T] {
+	return (&setFunc[T]{m: make(map[uint64][]T), hash: hash, eq: eq}).Add(items...)
+}
+
+func (s *setFunc[T]) find(item T) (h uint64, i int) {
+	h = s.hash(item)
+	for i, v := range s.m[h] {
+		if s.eq(v, item) {
+			return h, i
+		}
+	}
+
+	return h, -1
+}
+
+func (s *setFunc[T]) has(item T) bool {
+	_, i := s.find(item)
+	return i >= 0
+}
+
+// Add includes the specified items (one or more) to the set. The underlying
+// Set s is modified. If passed nothing it silently returns.
+func (s *setFunc[T]) Add(items ...T) Set[T] {
+	for _, item := range items {
+		if h, i := s.find(item); i < 0 {
+			s.m[h] = append(s.m[h], item)
+			s.size++
+		}
+	}
+
+	return s
+}
+
+// Remove deletes the specified items from the set.  The underlying Set s is
+// modified. If passed nothing it silently returns.
+func (s *setFunc[T]) Remove(items ...T) Set[T] {
+	for _, item := range items {
+		if h, i := s.find(item); i >= 0 {
+			s.removeAt(h, i)
+		}
+	}
+
+	return s
+}
+
+func (s *setFunc[T]) removeAt(h uint64, i int) {
+	bucket := s.m[h]
+	if len(bucket) == 1 {
+		delete(s.m, h)
+	} else {
+		bucket[i] = bucket[len(bucket)-1]
+		var zero T
+		bucket[len(bucket)-1] = zero
+		s.m[h] = bucket[:len(bucket)-1]
+	}
+	s.size--
+}
+
+// Pop  deletes and return an item from the set. The underlying Set s is
+// modified. If set is empty, nil is returned.
+func (s *setFunc[T]) Pop() (T, bool) {
+	for h, bucket := range s.m {
+		item := bucket[0]
+		s.removeAt(h, 0)
+		return item, true
+	}
+
+	var t T
+
+	return t, false
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of  the items exist.
+func (s *setFunc[T]) Has(items ...T) bool {
+	// assume checked for empty item, which not exist
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if !s.has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *setFunc[T]) Size() int     { return s.size }
+func (s *setFunc[T]) Clear()        { s.m, s.size = make(map[uint64][]T), 0 }
+func (s *setFunc[T]) IsEmpty() bool { return s.Size() == 0 }
+func (s *setFunc[T]) IsEqual(t Set[T]) bool {
+	// Force locking only if given set is threadsafe.
+	if conv, ok := t.(rwLocker); ok {
+		conv.RLock()
+		defer conv.RUnlock()
+	}
+
+	// return false if they are no the same size
+	if s.size != t.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSubset tests whether t is a subset of s.
+func (s *setFunc[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSuperset tests whether t is a superset of s.
+func (s *setFunc[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
+
+// Each traverses the items in the Set, calling the provided function for each
+// set member. Traversal will continue until all items in the Set have been
+// visited, or if the closure returns false.
+func (s *setFunc[T]) Each(f func(item T) bool) bool {
+	for _, bucket := range s.m {
+		for _, item := range bucket {
+			if !f(item) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Copy returns a new Set with a copy of s.
+func (s *setFunc[T]) Copy() Set[T] {
+	u := &setFunc[T]{m: make(map[uint64][]T, len(s.m)), size: s.size, hash: s.hash, eq: s.eq}
+	for h, bucket := range s.m {
+		u.m[h] = append([]T(nil), bucket...)
+	}
+	return u
+}
+
+// String returns a string representation of s
+func (s *setFunc[T]) String() string { return stringSet[T](s) }
+
+// List returns a slice of all items.
+func (s *setFunc[T]) List() []T {
+	list := make([]T, 0, s.size)
+	for _, bucket := range s.m {
+		list = append(list, bucket...)
+	}
+
+	return list
+}
+
+// Merge is like Union, however it modifies the current set it's applied on
+// with the given t set.
+func (s *setFunc[T]) Merge(t Set[T]) Set[T] {
+	t.Each(func(item T) bool {
+		s.Add(item)
+		return true
+	})
+
+	return s
+}
+
+// it's not the opposite of Merge.
+// Separate removes the set items containing in t from set s. Please aware that
+func (s *setFunc[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *setFunc[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *setFunc[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *setFunc[T]) Intersect(t Set[T]) Set[T] {
+	var drop []T
+	s.Each(func(item T) bool {
+		if !t.Has(item) {
+			drop = append(drop, item)
+		}
+		return true
+	})
+
+	return s.Remove(drop...)
+}
+
+func (s *setFunc[T]) SymmetricDifference(t Set[T]) Set[T] {
+	for _, item := range t.List() {
+		if h, i := s.find(item); i >= 0 {
+			s.removeAt(h, i)
+		} else {
+			s.m[h] = append(s.m[h], item)
+			s.size++
+		}
+	}
+
+	return s
+}
+
+func (s *setFunc[T]) UnionCopy(t Set[T]) Set[T]               { return s.Copy().Union(t) }
+func (s *setFunc[T]) IntersectCopy(t Set[T]) Set[T]           { return s.Copy().Intersect(t) }
+func (s *setFunc[T]) DifferenceCopy(t Set[T]) Set[T]          { return s.Copy().Difference(t) }
+func (s *setFunc[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] { return s.Copy().SymmetricDifference(t) }
@@ -0,0 +1,39 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetFunc(t *testing.T) {
+	// weak hash to force collisions
+	hash := func(s []int) uint64 { return uint64(len(s)) }
+	s := NewFunc(hash, slices.Equal[[]int], []int{1, 2}, []int{2, 1}, []int{1, 2}, []int{3})
+
+	if s.Size() != 3 {
+		t.Error("NewFunc: duplicates must be ignored, got", s)
+	}
+	if !s.Has([]int{2, 1}, []int{3}) || s.Has([]int{4}) {
+		t.Error("Has: unexpected membership, got", s)
+	}
+
+	s.Remove([]int{1, 2})
+	if s.Size() != 2 || s.Has([]int{1, 2}) || !s.Has([]int{2, 1}) {
+		t.Error("Remove: only equal item must be removed, got", s)
+	}
+
+	u := s.Copy()
+	u.Add([]int{5, 5})
+	if s.Size() != 2 || u.Size() != 3 || !u.IsSubset(s) {
+		t.Error("Copy: copy must be independent, got", s, u)
+	}
+
+	for !s.IsEmpty() {
+		if _, ok := s.Pop(); !ok {
+			t.Fatal("Pop: set is not empty, but nothing popped")
+		}
+	}
+	if s.Size() != 0 {
+		t.Error("Pop: size must be zero, got", s.Size())
+	}
+}
@@ -12,19 +12,23 @@ func mushHash(item Hashable) uint64 {
 	return h
 }
 
-type setAny[T Hashable] map[uint64]T
+type setAny[T Hashable] struct {
+	m map[uint64]T
+}
 
-func newAnyNonTS[T Hashable](items ...T) Set[T] { return make(setAny[T], len(items)).Add(items...) }
+func newAnyNonTS[T Hashable](items ...T) Set[T] {
+	return (&setAny[T]{make(map[uint64]T, len(items))}).Add(items...)
+}
 
 // Add includes the specified items (one or more) to the set. The underlying
 // Set s is modified. If passed nothing it silently returns.
-func (s setAny[T]) Add(items ...T) Set[T] {
+func (s *setAny[T]) Add(items ...T) Set[T] {
 	for _, item := range items {
 		h, err := item.Hash()
 		if err != nil {
 			panic(err)
 		}
-		s[h] = item
+		s.m[h] = item
 	}
 
 	return s
@@ -32,18 +36,18 @@ func (s setAny[T]) Add(items ...T) Set[T] {
 
 // Remove deletes the specified items from the set.  The underlying Set s is
 // modified. If passed nothing it silently returns.
-func (s setAny[T]) Remove(items ...T) Set[T] {
+func (s *setAny[T]) Remove(items ...T) Set[T] {
 	for _, item := range items {
-		delete(s, mushHash(item))
+		delete(s.m, mushHash(item))
 	}
 	return s
 }
 
 // Pop  deletes and return an item from the set. The underlying Set s is
 // modified. If set is empty, nil is returned.
-func (s setAny[T]) Pop() (T, bool) {
-	for h, item := range s {
-		defer delete(s, h)
+func (s *setAny[T]) Pop() (T, bool) {
+	for h, item := range s.m {
+		defer delete(s.m, h)
 		return item, true
 	}
 
@@ -54,24 +58,24 @@ func (s setAny[T]) Pop() (T, bool) {
 
 // Has looks for the existence of items passed. It returns false if nothing is
 // passed. For multiple items it returns true only if all of  the items exist.
-func (s setAny[T]) Has(items ...T) bool {
+func (s *setAny[T]) Has(items ...T) bool {
 	// assume checked for empty item, which not exist
 	if len(items) == 0 {
 		return false
 	}
 
 	for _, item := range items {
-		if _, ok := s[mushHash(item)]; !ok {
+		if _, ok := s.m[mushHash(item)]; !ok {
 			return false
 		}
 	}
 	return true
 }
 
-func (s setAny[T]) Size() int     { return len(s) }
-func (s setAny[T]) Clear()        { s = make(map[uint64]T) }
-func (s setAny[T]) IsEmpty() bool { return s.Size() == 0 }
-func (s setAny[T]) IsEqual(t Set[T]) bool {
+func (s *setAny[T]) Size() int     { return len(s.m) }
+func (s *setAny[T]) Clear()        { clear(s.m) }
+func (s *setAny[T]) IsEmpty() bool { return s.Size() == 0 }
+func (s *setAny[T]) IsEqual(t Set[T]) bool {
 	// Force locking only if given set is threadsafe.
 	if conv, ok := t.(rwLocker); ok {
 		conv.RLock()
@@ -79,32 +83,36 @@ func (s setAny[T]) IsEqual(t Set[T]) bool {
 	}
 
 	// return false if they are no the same size
-	if sameSize := len(s) == t.Size(); !sameSize {
+	if sameSize := len(s.m) == t.Size(); !sameSize {
 		return false
 	}
 
 	return t.Each(func(item T) bool {
-		_, ok := s[mushHash(item)]
+		_, ok := s.m[mushHash(item)]
 		return ok // if false, Each() will end
 	})
 }
 
 // IsSubset tests whether t is a subset of s.
-func (s setAny[T]) IsSubset(t Set[T]) bool {
+func (s *setAny[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
 	return t.Each(func(item T) bool {
-		_, ok := s[mushHash(item)]
+		_, ok := s.m[mushHash(item)]
 		return ok
 	})
 }
 
 // IsSuperset tests whether t is a superset of s.
-func (s setAny[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
+func (s *setAny[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
 
 // Each traverses the items in the Set, calling the provided function for each
 // set member. Traversal will continue until all items in the Set have been
 // visited, or if the closure returns false.
-func (s setAny[T]) Each(f func(item T) bool) bool {
-	for _, item := range s {
+func (s *setAny[T]) Each(f func(item T) bool) bool {
+	for _, item := range s.m {
 		if !f(item) {
 			return false
 		}
@@ -114,23 +122,23 @@ func (s setAny[T]) Each(f func(item T) bool) bool {
 }
 
 // Copy returns a new Set with a copy of s.
-func (s setAny[T]) Copy() Set[T] {
-	u := make(setAny[T])
-	for h, item := range s {
-		u[h] = item
+func (s *setAny[T]) Copy() Set[T] {
+	u := &setAny[T]{make(map[uint64]T, len(s.m))}
+	for h, item := range s.m {
+		u.m[h] = item
 	}
 	return u
 }
 
 // String returns a string representation of s
-func (s setAny[T]) String() string { return stringSet[T](s) }
+func (s *setAny[T]) String() string { return stringSet[T](s) }
 
 // List returns a slice of all items. There is also StringSlice() and
 // IntSlice() methods for returning slices of type string or int.
-func (s setAny[T]) List() []T {
-	list := make([]T, 0, len(s))
+func (s *setAny[T]) List() []T {
+	list := make([]T, 0, len(s.m))
 
-	for _, item := range s {
+	for _, item := range s.m {
 		list = append(list, item)
 	}
 
@@ -139,9 +147,9 @@ func (s setAny[T]) List() []T {
 
 // Merge is like Union, however it modifies the current set it's applied on
 // with the given t set.
-func (s setAny[T]) Merge(t Set[T]) Set[T] {
+func (s *setAny[T]) Merge(t Set[T]) Set[T] {
 	t.Each(func(item T) bool {
-		s[mushHash(item)] = item
+		s.m[mushHash(item)] = item
 		return true
 	})
 
@@ -150,4 +158,35 @@ func (s setAny[T]) Merge(t Set[T]) Set[T] {
 
 // it's not the opposite of Merge.
 // Separate removes the set items containing in t from set s. Please aware that
-func (s setAny[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+func (s *setAny[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *setAny[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *setAny[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *setAny[T]) Intersect(t Set[T]) Set[T] {
+	for h, item := range s.m {
+		if !t.Has(item) {
+			delete(s.m, h)
+		}
+	}
+
+	return s
+}
+
+func (s *setAny[T]) SymmetricDifference(t Set[T]) Set[T] {
+	for _, item := range t.List() {
+		h := mushHash(item)
+		if _, ok := s.m[h]; ok {
+			delete(s.m, h)
+		} else {
+			s.m[h] = item
+		}
+	}
+
+	return s
+}
+
+func (s *setAny[T]) UnionCopy(t Set[T]) Set[T]               { return s.Copy().Union(t) }
+func (s *setAny[T]) IntersectCopy(t Set[T]) Set[T]           { return s.Copy().Intersect(t) }
+func (s *setAny[T]) DifferenceCopy(t Set[T]) Set[T]          { return s.Copy().Difference(t) }
+func (s *setAny[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] { return s.Copy().SymmetricDifference(t) }
@@ -84,6 +84,10 @@ func (s *set[T]) IsEqual(t Set[T]) bool {
 
 // IsSubset tests whether t is a subset of s.
 func (s *set[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
 	return t.Each(func(item T) bool {
 		_, ok := s.m[item]
 		return ok
@@ -144,3 +148,33 @@ func (s *set[T]) Merge(t Set[T]) Set[T] {
 // it's not the opposite of Merge.
 // Separate removes the set items containing in t from set s. Please aware that
 func (s *set[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *set[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *set[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *set[T]) Intersect(t Set[T]) Set[T] {
+	for item := range s.m {
+		if !t.Has(item) {
+			delete(s.m, item)
+		}
+	}
+
+	return s
+}
+
+func (s *set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	for _, item := range t.List() {
+		if _, ok := s.m[item]; ok {
+			delete(s.m, item)
+		} else {
+			s.m[item] = null{}
+		}
+	}
+
+	return s
+}
+
+func (s *set[T]) UnionCopy(t Set[T]) Set[T]               { return s.Copy().Union(t) }
+func (s *set[T]) IntersectCopy(t Set[T]) Set[T]           { return s.Copy().Intersect(t) }
+func (s *set[T]) DifferenceCopy(t Set[T]) Set[T]          { return s.Copy().Difference(t) }
+func (s *set[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] { return s.Copy().SymmetricDifference(t) }
@@ -0,0 +1,296 @@
+package set
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// setSharded is a threadsafe set, split into shards, each of them is protected
+// by its own RWMutex. Unlike setm, operations on different shards don't block
+// each other, so it scales much better on write-heavy workloads with many
+// goroutines.
+type setSharded[T comparable] struct {
+	shards []setShard[T]
+	hash   func(T) uint64
+	size   atomic.Int64
+}
+
+type setShard[T comparable] struct {
+	sync.RWMutex
+	m map[T]struct{}
+}
+
+var _ Set[int] = (*setSharded[int])(nil)
+
+// NewSharded creates and initializes a new threadsafe Set, split into shards.
+// If shards is not positive, it's set to 4 * GOMAXPROCS. hash must distribute
+// items uniformly, since item's shard is hash(item) % shards.
+//
+// Operations on multiple items (Add, Remove, Has, etc.) lock shards one by
+// one, so they are not atomic: concurrent readers could observe partial
+// result.
+func NewSharded[T comparable](shards int, hash func(T) uint64, items ...T) Set[T] {
+	if hash == nil {
+		panic("hash function is required")
+	} else if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+
+	s := &setSharded[T]{shards: make([]setShard[T], shards), hash: hash}
+	for i := range s.shards {
+		s.shards[i].m = make(map[T]struct{})
+	}
+
+	return s.Add(items...)
+}
+
+func (s *setSharded[T]) shard(item T) *setShard[T] {
+	return &s.shards[s.hash(item)%uint64(len(s.shards))]
+}
+
+func (s *setSharded[T]) add(item T) {
+	sh := s.shard(item)
+	sh.Lock()
+	defer sh.Unlock()
+	if _, ok := sh.m[item]; !ok {
+		sh.m[item] = null{}
+		s.size.Add(1)
+	}
+}
+
+func (s *setSharded[T]) remove(item T) bool {
+	sh := s.shard(item)
+	sh.Lock()
+	defer sh.Unlock()
+	if _, ok := sh.m[item]; ok {
+		delete(sh.m, item)
+		s.size.Add(-1)
+		return true
+	}
+	return false
+}
+
+func (s *setSharded[T]) has(item T) bool {
+	sh := s.shard(item)
+	sh.RLock()
+	defer sh.RUnlock()
+	_, ok := sh.m[item]
+	return ok
+}
+
+// Add includes the specified items (one or more) to the set. The underlying
+// Set s is modified. If passed nothing it silently returns.
+func (s *setSharded[T]) Add(items ...T) Set[T] {
+	for _, item := range items {
+		s.add(item)
+	}
+
+	return s
+}
+
+// Remove deletes the specified items from the set.  The underlying Set s is
+// modified. If passed nothing it silently returns.
+func (s *setSharded[T]) Remove(items ...T) Set[T] {
+	for _, item := range items {
+		s.remove(item)
+	}
+
+	return s
+}
+
+// Pop  deletes and return an item from the set. The underlying Set s is
+// modified. If set is empty, nil is returned.
+func (s *setSharded[T]) Pop() (T, bool) {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.Lock()
+		for item := range sh.m {
+			delete(sh.m, item)
+			s.size.Add(-1)
+			sh.Unlock()
+			return item, true
+		}
+		sh.Unlock()
+	}
+
+	var t T
+
+	return t, false
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of  the items exist.
+func (s *setSharded[T]) Has(items ...T) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if !s.has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Size returns the number of items in a set. It doesn't lock any shard.
+func (s *setSharded[T]) Size() int     { return int(s.size.Load()) }
+func (s *setSharded[T]) IsEmpty() bool { return s.Size() == 0 }
+
+// Clear removes all items from the set.
+func (s *setSharded[T]) Clear() {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.Lock()
+		s.size.Add(-int64(len(sh.m)))
+		sh.m = make(map[T]struct{})
+		sh.Unlock()
+	}
+}
+
+func (s *setSharded[T]) IsEqual(t Set[T]) bool {
+	if t == Set[T](s) {
+		return true
+	}
+
+	// Force locking only if given set is threadsafe.
+	if conv, ok := t.(rwLocker); ok {
+		conv.RLock()
+		defer conv.RUnlock()
+	}
+
+	if s.Size() != t.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSubset tests whether t is a subset of s.
+func (s *setSharded[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
+	return t.Each(s.has)
+}
+
+// IsSuperset tests whether t is a superset of s.
+func (s *setSharded[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
+
+// Each traverses the items in the Set, calling the provided function for each
+// set member. Traversal will continue until all items in the Set have been
+// visited, or if the closure returns false.
+//
+// Items of each shard are copied before calling f, so f is called without any
+// lock held and may modify the set.
+func (s *setSharded[T]) Each(f func(item T) bool) bool {
+	var buf []T
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		buf = buf[:0]
+		for item := range sh.m {
+			buf = append(buf, item)
+		}
+		sh.RUnlock()
+
+		for _, item := range buf {
+			if !f(item) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Copy returns a new Set with a copy of s.
+func (s *setSharded[T]) Copy() Set[T] {
+	u := NewSharded(len(s.shards), s.hash).(*setSharded[T])
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.RLock()
+		for item := range sh.m {
+			u.shards[i].m[item] = null{}
+		}
+		u.size.Add(int64(len(sh.m)))
+		sh.RUnlock()
+	}
+
+	return u
+}
+
+// String returns a string representation of s
+func (s *setSharded[T]) String() string { return stringSet[T](s) }
+
+// List returns a slice of all items.
+func (s *setSharded[T]) List() []T {
+	list := make([]T, 0, s.Size())
+	s.Each(func(item T) bool {
+		list = append(list, item)
+		return true
+	})
+
+	return list
+}
+
+// Merge is like Union, however it modifies the current set it's applied on
+// with the given t set.
+func (s *setSharded[T]) Merge(t Set[T]) Set[T] {
+	if t == Set[T](s) {
+		return s
+	}
+
+	t.Each(func(item T) bool {
+		s.add(item)
+		return true
+	})
+
+	return s
+}
+
+// it's not the opposite of Merge.
+// Separate removes the set items containing in t from set s. Please aware that
+func (s *setSharded[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *setSharded[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *setSharded[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *setSharded[T]) Intersect(t Set[T]) Set[T] {
+	if t == Set[T](s) {
+		return s
+	}
+
+	s.Each(func(item T) bool {
+		if !t.Has(item) {
+			s.remove(item)
+		}
+		return true
+	})
+
+	return s
+}
+
+func (s *setSharded[T]) SymmetricDifference(t Set[T]) Set[T] {
+	if t == Set[T](s) {
+		s.Clear()
+		return s
+	}
+
+	for _, item := range t.List() {
+		if !s.remove(item) {
+			s.add(item)
+		}
+	}
+
+	return s
+}
+
+func (s *setSharded[T]) UnionCopy(t Set[T]) Set[T]      { return s.Copy().Union(t) }
+func (s *setSharded[T]) IntersectCopy(t Set[T]) Set[T]  { return s.Copy().Intersect(t) }
+func (s *setSharded[T]) DifferenceCopy(t Set[T]) Set[T] { return s.Copy().Difference(t) }
+func (s *setSharded[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] {
+	return s.Copy().SymmetricDifference(t)
+}
@@ -0,0 +1,82 @@
+package set
+
+import (
+	"sync"
+	"testing"
+)
+
+func intHash(i int) uint64 { return uint64(i) * 0x9E3779B97F4A7C15 }
+
+func TestSetSharded(t *testing.T) {
+	s := NewSharded(8, intHash, 1, 2, 3)
+
+	if s.Size() != 3 || !s.Has(1, 2, 3) {
+		t.Error("NewSharded: set must contain passed items, got", s)
+	}
+	if !s.IsEqual(New(1, 2, 3)) || !s.Copy().IsEqual(s) {
+		t.Error("IsEqual: sets must be equal")
+	}
+
+	// Each doesn't hold locks during callback, so modification is allowed
+	s.Each(func(item int) bool {
+		s.Remove(item)
+		return true
+	})
+	if !s.IsEmpty() {
+		t.Error("Each: items must be removed, got", s)
+	}
+}
+
+func TestSetSharded_Race(t *testing.T) {
+	s := NewSharded(0, intHash)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Add(i, i+1000)
+			s.Has(i)
+			s.Remove(i + 1000)
+			s.Size()
+		}(i)
+	}
+	wg.Wait()
+
+	if s.Size() != 100 {
+		t.Error("expected 100 items, got", s.Size())
+	}
+	for i := 0; i < 100; i++ {
+		if _, ok := s.Pop(); !ok {
+			t.Fatal("Pop: expected item")
+		}
+	}
+	if _, ok := s.Pop(); ok || s.Size() != 0 {
+		t.Error("Pop: set must be empty")
+	}
+}
+
+// benchmarkMixed runs workload with 10% writes and 90% reads from parallel
+// goroutines.
+func benchmarkMixed(b *testing.B, s Set[int]) {
+	const n = 1 << 16
+	for i := 0; i < n; i += 2 {
+		s.Add(i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			i = (i + 7919) % n
+			if i%10 == 0 {
+				s.Add(i)
+			} else {
+				s.Has(i)
+			}
+		}
+	})
+}
+
+func BenchmarkMixedMutex(b *testing.B)   { benchmarkMixed(b, wrapMutex(newNonTS[int]())) }
+func BenchmarkMixedSharded(b *testing.B) { benchmarkMixed(b, NewSharded(0, intHash)) }
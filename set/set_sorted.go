@@ -0,0 +1,227 @@
+package set
+
+import (
+	"github.com/quenbyako/ext/cmp"
+	"github.com/quenbyako/ext/slices"
+)
+
+// SortedSet is a Set, which keeps its items in ascending order. Each, List and
+// String traverse items from the smallest to the largest one.
+type SortedSet[T any] interface {
+	Set[T]
+	// Min returns the smallest item. If set is empty, ok is false.
+	Min() (T, bool)
+	// Max returns the largest item. If set is empty, ok is false.
+	Max() (T, bool)
+	// Between returns items in range [lo, hi] in ascending order.
+	Between(lo, hi T) []T
+}
+
+// sortedSet is backed by sorted slice: lookups are O(log n), insertions and
+// deletions are O(n), which is fine for small and read-mostly sets.
+type sortedSet[T any] struct {
+	items []T
+	cmp   func(a, b T) int
+}
+
+var _ SortedSet[int] = (*sortedSet[int])(nil)
+
+// NewSorted creates and initializes a new non-threadsafe SortedSet, populated
+// with items.
+func NewSorted[T cmp.Ordered](items ...T) SortedSet[T] {
+	return NewSortedFunc(cmp.Compare[T], items...)
+}
+
+// NewSortedFunc is like NewSorted, but items are ordered with cmp function,
+// which must return negative number if a < b, positive if a > b and zero, if
+// items are equal.
+func NewSortedFunc[T any](cmp func(a, b T) int, items ...T) SortedSet[T] {
+	s := &sortedSet[T]{cmp: cmp}
+	s.Add(items...)
+
+	return s
+}
+
+func (s *sortedSet[T]) search(item T) (int, bool) {
+	return slices.BinarySearchFunc(s.items, item, s.cmp)
+}
+
+// Add includes the specified items (one or more) to the set. The underlying
+// Set s is modified. If passed nothing it silently returns.
+func (s *sortedSet[T]) Add(items ...T) Set[T] {
+	for _, item := range items {
+		if i, ok := s.search(item); !ok {
+			s.items = slices.Insert(s.items, i, item)
+		}
+	}
+
+	return s
+}
+
+// Remove deletes the specified items from the set. The underlying Set s is
+// modified. If passed nothing it silently returns.
+func (s *sortedSet[T]) Remove(items ...T) Set[T] {
+	for _, item := range items {
+		if i, ok := s.search(item); ok {
+			s.items = slices.Delete(s.items, i, i+1)
+		}
+	}
+
+	return s
+}
+
+// Pop deletes and returns the smallest item of the set. The underlying Set s
+// is modified.
+func (s *sortedSet[T]) Pop() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+
+	item = s.items[0]
+	s.items = slices.Delete(s.items, 0, 1)
+
+	return item, true
+}
+
+// Has looks for the existence of items passed. It returns false if nothing is
+// passed. For multiple items it returns true only if all of  the items exist.
+func (s *sortedSet[T]) Has(items ...T) bool {
+	if len(items) == 0 {
+		return false
+	}
+
+	for _, item := range items {
+		if _, ok := s.search(item); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *sortedSet[T]) Size() int     { return len(s.items) }
+func (s *sortedSet[T]) Clear()        { s.items = nil }
+func (s *sortedSet[T]) IsEmpty() bool { return s.Size() == 0 }
+func (s *sortedSet[T]) IsEqual(t Set[T]) bool {
+	// Force locking only if given set is threadsafe.
+	if conv, ok := t.(rwLocker); ok {
+		conv.RLock()
+		defer conv.RUnlock()
+	}
+
+	if len(s.items) != t.Size() {
+		return false
+	}
+
+	return t.Each(func(item T) bool {
+		_, ok := s.search(item)
+		return ok
+	})
+}
+
+// IsSubset tests whether t is a subset of s.
+func (s *sortedSet[T]) IsSubset(t Set[T]) bool {
+	if t.Size() > s.Size() {
+		return false
+	}
+
+	return t.Each(func(item T) bool {
+		_, ok := s.search(item)
+		return ok
+	})
+}
+
+// IsSuperset tests whether t is a superset of s.
+func (s *sortedSet[T]) IsSuperset(t Set[T]) bool { return t.IsSubset(s) }
+
+// Each traverses the items in ascending order, calling the provided function
+// for each set member. Traversal will continue until all items in the Set have
+// been visited, or if the closure returns false.
+func (s *sortedSet[T]) Each(f func(item T) bool) bool {
+	for _, item := range s.items {
+		if !f(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns a new Set with a copy of s.
+func (s *sortedSet[T]) Copy() Set[T] { return &sortedSet[T]{items: slices.Clone(s.items), cmp: s.cmp} }
+
+// String returns a string representation of s
+func (s *sortedSet[T]) String() string { return stringList(s.items) }
+
+// List returns a slice of all items in ascending order.
+func (s *sortedSet[T]) List() []T { return slices.Clone(s.items) }
+
+// Merge is like Union, however it modifies the current set it's applied on
+// with the given t set.
+func (s *sortedSet[T]) Merge(t Set[T]) Set[T] {
+	t.Each(func(item T) bool {
+		s.Add(item)
+		return true
+	})
+
+	return s
+}
+
+// Separate removes the set items containing in t from set s. Please aware that
+// it's not the opposite of Merge.
+func (s *sortedSet[T]) Separate(t Set[T]) Set[T] { return s.Remove(t.List()...) }
+
+func (s *sortedSet[T]) Min() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+	return s.items[0], true
+}
+
+func (s *sortedSet[T]) Max() (item T, ok bool) {
+	if len(s.items) == 0 {
+		return item, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+func (s *sortedSet[T]) Between(lo, hi T) []T {
+	if s.cmp(lo, hi) > 0 {
+		return nil
+	}
+
+	from, _ := s.search(lo)
+	to, found := s.search(hi)
+	if found {
+		to++
+	}
+
+	return slices.Clone(s.items[from:to])
+}
+
+func (s *sortedSet[T]) Union(t Set[T]) Set[T]      { return s.Merge(t) }
+func (s *sortedSet[T]) Difference(t Set[T]) Set[T] { return s.Separate(t) }
+
+func (s *sortedSet[T]) Intersect(t Set[T]) Set[T] {
+	s.items = slices.DeleteFunc(s.items, func(item T) bool { return !t.Has(item) })
+
+	return s
+}
+
+func (s *sortedSet[T]) SymmetricDifference(t Set[T]) Set[T] {
+	for _, item := range t.List() {
+		if i, ok := s.search(item); ok {
+			s.items = slices.Delete(s.items, i, i+1)
+		} else {
+			s.items = slices.Insert(s.items, i, item)
+		}
+	}
+
+	return s
+}
+
+func (s *sortedSet[T]) UnionCopy(t Set[T]) Set[T]      { return s.Copy().Union(t) }
+func (s *sortedSet[T]) IntersectCopy(t Set[T]) Set[T]  { return s.Copy().Intersect(t) }
+func (s *sortedSet[T]) DifferenceCopy(t Set[T]) Set[T] { return s.Copy().Difference(t) }
+func (s *sortedSet[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] {
+	return s.Copy().SymmetricDifference(t)
+}
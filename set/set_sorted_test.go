@@ -0,0 +1,65 @@
+package set
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetSorted_Order(t *testing.T) {
+	s := NewSorted(5, 1, 4, 2, 3, 3)
+
+	if s.Size() != 5 {
+		t.Error("NewSorted: duplicates must be ignored, got", s)
+	}
+	if got := s.List(); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Error("List: items must be sorted, got", got)
+	}
+	if got := s.String(); got != "set[1, 2, 3, 4, 5]" {
+		t.Error("String: items must be sorted, got", got)
+	}
+
+	if v, ok := s.Min(); !ok || v != 1 {
+		t.Error("Min: expected 1, got", v)
+	}
+	if v, ok := s.Max(); !ok || v != 5 {
+		t.Error("Max: expected 5, got", v)
+	}
+
+	if v, _ := s.Pop(); v != 1 {
+		t.Error("Pop: smallest item expected, got", v)
+	}
+	s.Remove(4)
+	if got := s.List(); !reflect.DeepEqual(got, []int{2, 3, 5}) {
+		t.Error("Remove: unexpected items, got", got)
+	}
+}
+
+func TestSetSorted_Between(t *testing.T) {
+	s := NewSorted(10, 20, 30, 40)
+
+	for _, tt := range []struct {
+		lo, hi int
+		want   []int
+	}{
+		{10, 30, []int{10, 20, 30}},
+		{11, 39, []int{20, 30}},
+		{0, 5, []int{}},
+		{30, 10, nil},
+	} {
+		if got := s.Between(tt.lo, tt.hi); len(got) != len(tt.want) || len(got) > 0 && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Between(%v, %v) = %v, want %v", tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestSetSorted_Func(t *testing.T) {
+	s := NewSortedFunc(func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }, "b", "A", "c", "a")
+
+	if got := s.List(); !reflect.DeepEqual(got, []string{"A", "b", "c"}) {
+		t.Error("NewSortedFunc: unexpected items, got", got)
+	}
+	if !s.IsEqual(New("a", "B", "C")) {
+		t.Error("IsEqual: sets must be equal by comparator")
+	}
+}
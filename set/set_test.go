@@ -2,6 +2,8 @@ package set
 
 import (
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -182,3 +184,302 @@ func BenchmarkIntersection100000(b *testing.B) {
 func BenchmarkIntersection1000000(b *testing.B) {
 	benchmarkIntersection(b, 1000000)
 }
+
+func Test_Constructors(t *testing.T) {
+	if s := New(1, 2, 2); s.Size() != 2 || !s.Has(1, 2) {
+		t.Error("New: set must contain passed items, got", s)
+	}
+
+	s := NewSync("a", "b")
+	if _, ok := s.(rwLocker); !ok {
+		t.Error("NewSync: set must be threadsafe")
+	}
+	if !s.Has("a", "b") {
+		t.Error("NewSync: set must contain passed items, got", s)
+	}
+
+	if s := FromSlice([]int{1, 1, 3}); s.Size() != 2 || !s.Has(1, 3) {
+		t.Error("FromSlice: set must contain items of slice, got", s)
+	}
+
+	if s := FromKeys(map[string]int{"x": 1, "y": 2}); s.Size() != 2 || !s.Has("x", "y") {
+		t.Error("FromKeys: set must contain keys of map, got", s)
+	}
+}
+
+func Test_AllCollect(t *testing.T) {
+	s := NewSync(1, 2, 3)
+
+	if u := Collect(All(s)); !u.IsEqual(s) {
+		t.Error("Collect(All(s)) must be equal to s, got", u)
+	}
+
+	count := 0
+	All(s)(func(int) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Error("All: iteration must stop when yield returns false, got", count)
+	}
+}
+
+func Test_FilterMapReduce(t *testing.T) {
+	s := NewSync(1, 2, 3, 4)
+
+	even := Filter(s, func(i int) bool { return i%2 == 0 })
+	if !even.IsEqual(New(2, 4)) {
+		t.Error("Filter: unexpected items, got", even)
+	}
+	if _, ok := even.(rwLocker); !ok {
+		t.Error("Filter: result must have the same type as source set")
+	}
+	if s.Size() != 4 {
+		t.Error("Filter: source set must not be modified")
+	}
+
+	if m := Map(s, func(i int) string { return strconv.Itoa(i % 2) }); !m.IsEqual(New("0", "1")) {
+		t.Error("Map: unexpected items, got", m)
+	}
+
+	if sum := Reduce(s, 0, func(acc, i int) int { return acc + i }); sum != 10 {
+		t.Error("Reduce: expected 10, got", sum)
+	}
+}
+
+func Test_IsDisjoint(t *testing.T) {
+	s := NewSync(1, 2, 3)
+
+	if !IsDisjoint(s, New(4, 5)) {
+		t.Error("IsDisjoint: sets have no common items")
+	}
+	if IsDisjoint(New(10, 11, 12, 3), s) {
+		t.Error("IsDisjoint: sets have common item 3")
+	}
+	if !IsDisjoint(s, New[int]()) {
+		t.Error("IsDisjoint: empty set is disjoint with any set")
+	}
+}
+
+func Test_InPlaceOperations(t *testing.T) {
+	for name, newSet := range map[string]func(...int) Set[int]{
+		"nots":    New[int],
+		"ts":      NewSync[int],
+		"sorted":  func(i ...int) Set[int] { return NewSorted(i...) },
+		"sharded": func(i ...int) Set[int] { return NewSharded(4, intHash, i...) },
+		"func": func(i ...int) Set[int] {
+			return NewFunc(func(i int) uint64 { return uint64(i) }, func(a, b int) bool { return a == b }, i...)
+		},
+	} {
+		other := New(3, 4, 5)
+
+		for _, tt := range []struct {
+			op       string
+			inPlace  func(s Set[int]) Set[int]
+			copied   func(s Set[int]) Set[int]
+			expected Set[int]
+		}{
+			{"Union", func(s Set[int]) Set[int] { return s.Union(other) }, func(s Set[int]) Set[int] { return s.UnionCopy(other) }, New(1, 2, 3, 4, 5)},
+			{"Intersect", func(s Set[int]) Set[int] { return s.Intersect(other) }, func(s Set[int]) Set[int] { return s.IntersectCopy(other) }, New(3)},
+			{"Difference", func(s Set[int]) Set[int] { return s.Difference(other) }, func(s Set[int]) Set[int] { return s.DifferenceCopy(other) }, New(1, 2)},
+			{"SymmetricDifference", func(s Set[int]) Set[int] { return s.SymmetricDifference(other) }, func(s Set[int]) Set[int] { return s.SymmetricDifferenceCopy(other) }, New(1, 2, 4, 5)},
+		} {
+			s := newSet(1, 2, 3)
+			if got := tt.copied(s); !got.IsEqual(tt.expected) || s.Size() != 3 {
+				t.Errorf("%v.%vCopy: got %v, source %v", name, tt.op, got, s)
+			}
+			if got := tt.inPlace(s); !s.IsEqual(tt.expected) || got != s {
+				t.Errorf("%v.%v: got %v", name, tt.op, s)
+			}
+		}
+
+		// operations with itself must not deadlock
+		s := newSet(1, 2)
+		if s.Intersect(s); s.Size() != 2 {
+			t.Errorf("%v.Intersect(self): got %v", name, s)
+		}
+		if s.SymmetricDifference(s); s.Size() != 0 {
+			t.Errorf("%v.SymmetricDifference(self): got %v", name, s)
+		}
+	}
+}
+
+func Test_AddSlice(t *testing.T) {
+	for _, s := range []Set[int]{NewWithCapacity[int](2), NewSync(1), NewSorted(1), wrapMutex[int](NewSorted(1))} {
+		items := []int{1, 2, 3, 4, 5}
+		if AddSlice(s, items); !s.IsEqual(New(items...)) {
+			t.Error("AddSlice: unexpected items, got", s)
+		}
+		if RemoveSlice(s, items[1:]); !s.IsEqual(New(1)) {
+			t.Error("RemoveSlice: unexpected items, got", s)
+		}
+	}
+}
+
+func benchmarkAdd(b *testing.B, add func(Set[int], []int)) {
+	items := make([]int, 100000)
+	for i := range items {
+		items[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := New[int]()
+		s.Add(0)
+		add(s, items)
+	}
+}
+
+func BenchmarkAddVariadic(b *testing.B) {
+	benchmarkAdd(b, func(s Set[int], items []int) { s.Add(items...) })
+}
+
+func BenchmarkAddSlice(b *testing.B) {
+	benchmarkAdd(b, func(s Set[int], items []int) { AddSlice(s, items) })
+}
+
+func Test_PopFuncExtract(t *testing.T) {
+	s := NewSync(1, 2, 3, 4, 5)
+
+	if item, ok := PopFunc(s, func(i int) bool { return i > 4 }); !ok || item != 5 || s.Has(5) {
+		t.Error("PopFunc: expected 5 to be popped, got", item, ok, s)
+	}
+	if _, ok := PopFunc(s, func(i int) bool { return i > 100 }); ok || s.Size() != 4 {
+		t.Error("PopFunc: nothing must be popped, got", s)
+	}
+
+	even := Extract(s, func(i int) bool { return i%2 == 0 })
+	if !even.IsEqual(New(2, 4)) || !s.IsEqual(New(1, 3)) {
+		t.Error("Extract: unexpected result, got", even, s)
+	}
+	if _, ok := even.(rwLocker); !ok {
+		t.Error("Extract: result must have the same type as source set")
+	}
+}
+
+func Test_PopFuncExtractConcurrent(t *testing.T) {
+	const n, workers = 1000, 8
+
+	for name, newSet := range map[string]func() Set[int]{
+		"sync":    func() Set[int] { return NewSync[int]() },
+		"sharded": func() Set[int] { return NewSharded[int](4, intHash) },
+	} {
+		s := newSet()
+		for i := 0; i < n; i++ {
+			s.Add(i)
+		}
+
+		var mu sync.Mutex
+		seen := make(map[int]int)
+		record := func(item int) {
+			mu.Lock()
+			seen[item]++
+			mu.Unlock()
+		}
+
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for {
+					if w%2 == 0 {
+						// match uses the same set, it must not deadlock
+						item, ok := PopFunc(s, func(i int) bool { return s.Has(i) })
+						if !ok {
+							return
+						}
+						record(item)
+						continue
+					}
+
+					res := Extract(s, func(i int) bool { return i%7 == w })
+					res.Each(func(item int) bool { record(item); return true })
+					if s.IsEmpty() {
+						return
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		if len(seen) != n || !s.IsEmpty() {
+			t.Error(name, ": expected all items to be removed, got", len(seen), s.Size())
+		}
+		for item, count := range seen {
+			if count != 1 {
+				t.Error(name, ": item returned more than once:", item, count)
+			}
+		}
+	}
+}
+
+func Test_Compare(t *testing.T) {
+	hash := func(i int) uint64 { return uint64(i) }
+	eq := func(a, b int) bool { return a == b }
+
+	for name, tt := range map[string]struct{ a, b Set[int] }{
+		"sync and plain":    {NewSync(1, 2, 3), New(3, 4)},
+		"plain and sync":    {New(1, 2, 3), NewSync(3, 4)},
+		"sorted and func":   {NewSorted(1, 2, 3), NewFunc(hash, eq, 3, 4)},
+		"sharded and plain": {NewSharded(4, hash, 1, 2, 3), New(3, 4)},
+	} {
+		a, b := tt.a.Copy(), tt.b.Copy()
+		onlyA, onlyB, both := Compare(tt.a, tt.b)
+
+		if !onlyA.IsEqual(New(1, 2)) || !onlyB.IsEqual(New(4)) || !both.IsEqual(New(3)) {
+			t.Error(name, ": unexpected result, got", onlyA, onlyB, both)
+		}
+		if reflect.TypeOf(onlyA) != reflect.TypeOf(tt.a) || reflect.TypeOf(both) != reflect.TypeOf(tt.a) {
+			t.Error(name, ": onlyA and both must have type of a, got", reflect.TypeOf(onlyA), reflect.TypeOf(both))
+		}
+		if reflect.TypeOf(onlyB) != reflect.TypeOf(tt.b) {
+			t.Error(name, ": onlyB must have type of b, got", reflect.TypeOf(onlyB))
+		}
+		if !tt.a.IsEqual(a) || !tt.b.IsEqual(b) {
+			t.Error(name, ": arguments must not be modified, got", tt.a, tt.b)
+		}
+	}
+}
+
+func Test_StringSorted(t *testing.T) {
+	s := NewSync(10, 2, 1)
+
+	if got := s.String(); got != "set[1, 10, 2]" {
+		t.Error("String: output must be sorted by string representation, got", got)
+	}
+	if got := StringSorted(s, func(a, b int) bool { return a < b }); got != "set[1, 2, 10]" {
+		t.Error("StringSorted: unexpected output, got", got)
+	}
+	if got := ListSorted(s, func(a, b int) bool { return a > b }); !reflect.DeepEqual(got, []int{10, 2, 1}) {
+		t.Error("ListSorted: unexpected output, got", got)
+	}
+}
+
+func Test_IsProperSubset(t *testing.T) {
+	a, b := NewSync(1, 2), New(1, 2, 3)
+
+	if !IsProperSubset(a, b) || IsProperSubset(b, a) || IsProperSubset(a, a) {
+		t.Error("IsProperSubset: unexpected result")
+	}
+	if !IsProperSuperset(b, a) || IsProperSuperset(a, b) || IsProperSuperset(b, b) {
+		t.Error("IsProperSuperset: unexpected result")
+	}
+	if New(1).IsSubset(b) {
+		t.Error("IsSubset: larger set can't be a subset")
+	}
+}
+
+func Test_MapConversions(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 1}
+
+	if s := FromValues(m); !s.IsEqual(New(1, 2)) {
+		t.Error("FromValues: unexpected items, got", s)
+	}
+	if got := ToMap(NewSync("x", "y")); !reflect.DeepEqual(got, map[string]struct{}{"x": {}, "y": {}}) {
+		t.Error("ToMap: unexpected result, got", got)
+	}
+	if s := FromKeys(ToMap(New(1, 2))); !s.IsEqual(New(1, 2)) {
+		t.Error("FromKeys(ToMap()): round trip failed, got", s)
+	}
+}
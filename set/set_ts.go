@@ -2,12 +2,29 @@ package set
 
 import (
 	"sync"
+	"sync/atomic"
 )
 
 // setm defines a thread safe set data structure.
+//
+// Methods, which accept callbacks (Each) call them without holding the lock,
+// iterating over a snapshot of items, so callbacks may freely use the same
+// set. Methods, which accept another set, detect when it's the same set and
+// don't lock it twice.
 type setm[T any] struct {
 	s            Set[T]
 	sync.RWMutex // we name it because we don't want to expose it
+
+	// gen is incremented on each write lock, so cached snapshot is valid
+	// only while its generation is equal to gen. gen is modified only under
+	// write lock.
+	gen  uint64
+	snap atomic.Pointer[snapshot[T]]
+}
+
+type snapshot[T any] struct {
+	gen   uint64
+	items []T
 }
 
 var _ interface {
@@ -20,6 +37,28 @@ var _ interface {
 // size is created.
 func wrapMutex[T any](s Set[T]) Set[T] { return &setm[T]{s: s} }
 
+// lockWrite takes write lock and invalidates cached snapshot.
+func (s *setm[T]) lockWrite() {
+	s.Lock()
+	s.gen++
+}
+
+// snapshot returns items of the set at the moment of the call. Returned slice
+// is shared between callers and must not be modified.
+func (s *setm[T]) snapshot() []T {
+	s.RLock()
+	defer s.RUnlock()
+
+	if snap := s.snap.Load(); snap != nil && snap.gen == s.gen {
+		return snap.items
+	}
+
+	snap := &snapshot[T]{gen: s.gen, items: s.s.List()}
+	s.snap.Store(snap)
+
+	return snap.items
+}
+
 type rwLocker interface {
 	RLock()
 	RUnlock()
@@ -28,7 +67,7 @@ type rwLocker interface {
 // Add includes the specified items (one or more) to the set. The underlying
 // Set s is modified. If passed nothing it silently returns.
 func (s *setm[T]) Add(items ...T) Set[T] {
-	s.Lock()
+	s.lockWrite()
 	defer s.Unlock()
 	s.s = s.s.Add(items...)
 
@@ -38,7 +77,7 @@ func (s *setm[T]) Add(items ...T) Set[T] {
 // Remove deletes the specified items from the set.  The underlying Set s is
 // modified. If passed nothing it silently returns.
 func (s *setm[T]) Remove(items ...T) Set[T] {
-	s.Lock()
+	s.lockWrite()
 	defer s.Unlock()
 	s.s = s.s.Remove(items...)
 
@@ -46,9 +85,9 @@ func (s *setm[T]) Remove(items ...T) Set[T] {
 }
 
 func (s *setm[T]) Separate(t Set[T]) Set[T] {
-	s.Lock()
+	s.lockWrite()
 	defer s.Unlock()
-	s.s = s.s.Separate(t)
+	s.s = s.s.Separate(s.other(t))
 
 	return s
 }
@@ -62,7 +101,7 @@ func (s *setm[T]) String() string {
 // Pop  deletes and return an item from the set. The underlying Set s is
 // modified. If set is empty, nil is returned.
 func (s *setm[T]) Pop() (T, bool) {
-	s.Lock()
+	s.lockWrite()
 	defer s.Unlock()
 	return s.s.Pop()
 }
@@ -84,7 +123,7 @@ func (s *setm[T]) Size() int {
 
 // Clear removes all items from the set.
 func (s *setm[T]) Clear() {
-	s.Lock()
+	s.lockWrite()
 	defer s.Unlock()
 	s.s.Clear()
 }
@@ -93,7 +132,7 @@ func (s *setm[T]) Clear() {
 func (s *setm[T]) IsEqual(t Set[T]) bool {
 	s.RLock()
 	defer s.RUnlock()
-	return s.s.IsEqual(t)
+	return s.s.IsEqual(s.other(t))
 }
 
 func (s *setm[T]) IsEmpty() bool {
@@ -106,28 +145,33 @@ func (s *setm[T]) IsEmpty() bool {
 func (s *setm[T]) IsSubset(t Set[T]) bool {
 	s.RLock()
 	defer s.RUnlock()
-	return s.s.IsSubset(t)
+	return s.s.IsSubset(s.other(t))
 }
 
 func (s *setm[T]) IsSuperset(t Set[T]) bool {
 	s.RLock()
 	defer s.RUnlock()
-	return s.s.IsSuperset(t)
+	return s.s.IsSuperset(s.other(t))
 }
 
+// Each traverses a snapshot of items, calling f without holding the lock, so f
+// may safely modify the set or call any of its methods. Modifications made
+// during traversal are not visible to it.
 func (s *setm[T]) Each(f func(item T) bool) bool {
-	s.RLock()
-	defer s.RUnlock()
-	return s.s.Each(f)
-}
+	for _, item := range s.snapshot() {
+		if !f(item) {
+			return false
+		}
+	}
 
-// List returns a slice of all items.
-func (s *setm[T]) List() []T {
-	s.RLock()
-	defer s.RUnlock()
-	return s.s.List()
+	return true
 }
 
+// List returns a slice of all items. Repeated calls without modifications in
+// between reuse same cached snapshot, copying it without locking the set for
+// iteration.
+func (s *setm[T]) List() []T { return append([]T(nil), s.snapshot()...) }
+
 func (s *setm[T]) Copy() Set[T] {
 	s.RLock()
 	defer s.RUnlock()
@@ -135,9 +179,74 @@ func (s *setm[T]) Copy() Set[T] {
 }
 
 func (s *setm[T]) Merge(t Set[T]) Set[T] {
-	s.Lock()
+	s.lockWrite()
+	defer s.Unlock()
+	s.s = s.s.Merge(s.other(t))
+
+	return s
+}
+
+// other returns set, which is safe to use while s is locked: if t is s itself,
+// then underlying set is returned to avoid recursive locking.
+func (s *setm[T]) other(t Set[T]) Set[T] {
+	if t == Set[T](s) {
+		return s.s
+	}
+	return t
+}
+
+func (s *setm[T]) Union(t Set[T]) Set[T] {
+	s.lockWrite()
 	defer s.Unlock()
-	s.s = s.s.Merge(t)
+	s.s = s.s.Union(s.other(t))
 
 	return s
 }
+
+func (s *setm[T]) Intersect(t Set[T]) Set[T] {
+	s.lockWrite()
+	defer s.Unlock()
+	s.s = s.s.Intersect(s.other(t))
+
+	return s
+}
+
+func (s *setm[T]) Difference(t Set[T]) Set[T] {
+	s.lockWrite()
+	defer s.Unlock()
+	s.s = s.s.Difference(s.other(t))
+
+	return s
+}
+
+func (s *setm[T]) SymmetricDifference(t Set[T]) Set[T] {
+	s.lockWrite()
+	defer s.Unlock()
+	s.s = s.s.SymmetricDifference(s.other(t))
+
+	return s
+}
+
+func (s *setm[T]) UnionCopy(t Set[T]) Set[T] {
+	s.RLock()
+	defer s.RUnlock()
+	return wrapMutex(s.s.UnionCopy(s.other(t)))
+}
+
+func (s *setm[T]) IntersectCopy(t Set[T]) Set[T] {
+	s.RLock()
+	defer s.RUnlock()
+	return wrapMutex(s.s.IntersectCopy(s.other(t)))
+}
+
+func (s *setm[T]) DifferenceCopy(t Set[T]) Set[T] {
+	s.RLock()
+	defer s.RUnlock()
+	return wrapMutex(s.s.DifferenceCopy(s.other(t)))
+}
+
+func (s *setm[T]) SymmetricDifferenceCopy(t Set[T]) Set[T] {
+	s.RLock()
+	defer s.RUnlock()
+	return wrapMutex(s.s.SymmetricDifferenceCopy(s.other(t)))
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestSet_New(t *testing.T) {
@@ -332,3 +333,57 @@ func TestSet_RaceAdd(t *testing.T) {
 		}(i)
 	}
 }
+
+func TestSet_Reentrancy(t *testing.T) {
+	s := NewSync(1, 2, 3)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		// callback uses the same set
+		s.Each(func(item int) bool {
+			s.Add(item + 10)
+			s.Remove(item)
+			return s.Has(item + 10)
+		})
+
+		// operations with itself
+		s.Merge(s)
+		s.IsEqual(s)
+		s.IsSubset(s)
+		s.IsSuperset(s)
+		s.Union(s)
+		s.Intersect(s)
+		s.UnionCopy(s)
+		s.Separate(s)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlock: set methods are not reentrant")
+	}
+
+	if !s.IsEmpty() {
+		t.Error("Separate: set must be empty, got", s)
+	}
+}
+
+func TestSet_Snapshot(t *testing.T) {
+	s := NewSync(1, 2).(*setm[int])
+
+	first := s.List()
+	if len(first) != 2 {
+		t.Fatal("List: expected 2 items, got", first)
+	}
+	if cached := s.snapshot(); &cached[0] != &s.snapshot()[0] {
+		t.Error("Snapshot: unmodified set must reuse cached snapshot")
+	}
+
+	first[0] = 100 // returned slice is a copy
+	s.Add(3)
+	if got := s.List(); len(got) != 3 || s.Has(100) {
+		t.Error("Snapshot: snapshot must be invalidated after modification, got", got)
+	}
+}
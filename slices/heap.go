@@ -0,0 +1,51 @@
+package slices
+
+// minimal binary heap over slice, used by heap-based algorithms of this
+// package. h[0] is the minimal element according to cmp.
+
+func heapPush[T any](h []T, item T, cmp func(a, b T) int) []T {
+	h = append(h, item)
+	heapUp(h, len(h)-1, cmp)
+	return h
+}
+
+func heapPop[T any](h []T, cmp func(a, b T) int) ([]T, T) {
+	n := len(h) - 1
+	h[0], h[n] = h[n], h[0]
+	heapDown(h[:n], 0, cmp)
+	return h[:n], h[n]
+}
+
+func heapUp[T any](h []T, i int, cmp func(a, b T) int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if cmp(h[i], h[parent]) >= 0 {
+			return
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+func heapDown[T any](h []T, i int, cmp func(a, b T) int) {
+	for {
+		smallest, left, right := i, 2*i+1, 2*i+2
+		if left < len(h) && cmp(h[left], h[smallest]) < 0 {
+			smallest = left
+		}
+		if right < len(h) && cmp(h[right], h[smallest]) < 0 {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
+
+func heapify[T any](h []T, cmp func(a, b T) int) {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		heapDown(h, i, cmp)
+	}
+}
@@ -0,0 +1,61 @@
+package slices
+
+// Iterators are plain funcs, not iter.Seq, to keep go1.22 compatibility.
+//
+// See also [All], [Values], [Collect] and [AppendSeq].
+
+// Enumerate returns an iterator over index-value pairs in the slice. It's the
+// same as [All], and exists for those, who are looking for familiar name.
+func Enumerate[S ~[]T, T any](s S) func(yield func(int, T) bool) { return All(s) }
+
+// Lazy combinators below allow to build transformation chains without
+// allocating intermediate slice on each step:
+//
+//	res := slices.Collect(slices.TakeSeq(slices.RemapSeq(slices.FilterSeq(
+//		slices.Values(items), isValid), toDTO), 10))
+//
+// Each element passes the whole chain before next one is taken from source.
+
+// FilterSeq returns an iterator over elements of seq satisfying f.
+func FilterSeq[T any](seq func(yield func(T) bool), f func(T) bool) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		seq(func(v T) bool { return !f(v) || yield(v) })
+	}
+}
+
+// RemapSeq returns an iterator over elements of seq, remapped by f.
+func RemapSeq[E, T any](seq func(yield func(E) bool), f func(E) T) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		seq(func(v E) bool { return yield(f(v)) })
+	}
+}
+
+// TakeSeq returns an iterator over first n elements of seq. Source sequence
+// is stopped right after n-th element.
+func TakeSeq[T any](seq func(yield func(T) bool), n int) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		taken := 0
+		seq(func(v T) bool {
+			taken++
+			return yield(v) && taken < n
+		})
+	}
+}
+
+// SkipSeq returns an iterator over elements of seq, except first n of them.
+func SkipSeq[T any](seq func(yield func(T) bool), n int) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		skipped := 0
+		seq(func(v T) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package slices
+
+import (
+	"math/rand/v2"
+)
+
+// Shuffle pseudo-randomizes the order of elements of s in place, using
+// Fisher–Yates algorithm, and returns s back. If src is nil, global random
+// source is used.
+func Shuffle[S ~[]T, T any](s S, src rand.Source) S {
+	intN := randIntN(src)
+	for i := len(s) - 1; i > 0; i-- {
+		j := intN(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return s
+}
+
+// Sample returns n pseudo-random elements of s, each element is selected at
+// most once. If n >= len(s), it returns a shuffled copy of s. Sample uses
+// reservoir sampling, so it makes a single pass over s and allocates only n
+// elements. Order of selected elements is not specified.
+//
+// If src is nil, global random source is used.
+func Sample[S ~[]T, T any](s S, n int, src rand.Source) S {
+	if n <= 0 {
+		return S{}
+	} else if n >= len(s) {
+		return Shuffle(Clone(s), src)
+	}
+
+	intN := randIntN(src)
+	res := Clone(s[:n])
+	for i := n; i < len(s); i++ {
+		if j := intN(i + 1); j < n {
+			res[j] = s[i]
+		}
+	}
+
+	return res
+}
+
+func randIntN(src rand.Source) func(int) int {
+	if src == nil {
+		return rand.IntN
+	}
+
+	return rand.New(src).IntN
+}
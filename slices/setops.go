@@ -0,0 +1,66 @@
+package slices
+
+// Set operations below treat slices as ordered sets: results contain each item
+// only once, in order of its first occurrence in the arguments. Map-backed
+// versions are O(len(a) + len(b)), Func versions are O(len(a) * len(b)).
+
+// Intersect returns items of a, which are also present in b.
+func Intersect[S ~[]T, T comparable](a, b S) S {
+	inB := ToMap(b)
+	res := make(S, 0, min(len(a), len(b)))
+	for _, item := range a {
+		if _, ok := inB[item]; ok {
+			res = append(res, item)
+			// deleting to not add duplicates of a
+			delete(inB, item)
+		}
+	}
+
+	return Clip(res)
+}
+
+// IntersectFunc is like [Intersect], but uses eq to compare items.
+func IntersectFunc[S ~[]T, T any](a, b S, eq func(T, T) bool) S {
+	res := make(S, 0, min(len(a), len(b)))
+	for _, item := range a {
+		match := func(other T) bool { return eq(item, other) }
+		if ContainsFunc(b, match) && !ContainsFunc(res, match) {
+			res = append(res, item)
+		}
+	}
+
+	return Clip(res)
+}
+
+// Union returns items which are present at least in one of a and b.
+func Union[S ~[]T, T comparable](a, b S) S { return Uniq(Concat(a, b)) }
+
+// UnionFunc is like [Union], but uses eq to compare items.
+func UnionFunc[S ~[]T, T any](a, b S, eq func(T, T) bool) S { return UniqFunc(Concat(a, b), eq) }
+
+// Subtract returns items of a, which are not present in b.
+func Subtract[S ~[]T, T comparable](a, b S) S {
+	skip := ToMap(b)
+	res := make(S, 0, len(a))
+	for _, item := range a {
+		if _, ok := skip[item]; !ok {
+			res = append(res, item)
+			skip[item] = struct{}{}
+		}
+	}
+
+	return Clip(res)
+}
+
+// SubtractFunc is like [Subtract], but uses eq to compare items.
+func SubtractFunc[S ~[]T, T any](a, b S, eq func(T, T) bool) S {
+	res := make(S, 0, len(a))
+	for _, item := range a {
+		match := func(other T) bool { return eq(item, other) }
+		if !ContainsFunc(b, match) && !ContainsFunc(res, match) {
+			res = append(res, item)
+		}
+	}
+
+	return Clip(res)
+}
@@ -9,6 +9,8 @@
 package slices
 
 import (
+	"errors"
+
 	"github.com/quenbyako/ext/cmp"
 )
 
@@ -21,6 +23,24 @@ func ToMap[S ~[]T, T comparable](s S) map[T]struct{} {
 	return res
 }
 
+// Associate builds a map from s, using f to get key and value of each item.
+// If f returns same key for several items, the last one wins.
+func Associate[S ~[]E, E any, K comparable, V any](s S, f func(E) (K, V)) map[K]V {
+	res := make(map[K]V, len(s))
+	for _, item := range s {
+		k, v := f(item)
+		res[k] = v
+	}
+
+	return res
+}
+
+// KeyBy builds a map from s, where each item is indexed by key. If key returns
+// same value for several items, the last one wins.
+func KeyBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]E {
+	return Associate(s, func(item E) (K, E) { return key(item), item })
+}
+
 func IndexEq[S ~[]T, T cmp.Eq[T]](s S, v T) int {
 	return IndexFunc(s, func(i T) bool { return i.Eq(v) })
 }
@@ -43,6 +63,30 @@ func IndexLastFunc[S ~[]T, T any](s S, f func(T) bool) int {
 	return -1
 }
 
+// Coalesce returns the first non-zero value of vals, or zero value, if all of
+// them are zero. It's useful for defaulting chains:
+//
+//	addr := slices.Coalesce(os.Getenv("ADDR"), cfg.Addr, "localhost:8080")
+func Coalesce[T comparable](vals ...T) (res T) {
+	for _, v := range vals {
+		if v != res {
+			return v
+		}
+	}
+
+	return res
+}
+
+// FirstFunc returns the first element of s satisfying f. If there is no such
+// element, it returns zero value and false.
+func FirstFunc[S ~[]T, T any](s S, f func(T) bool) (res T, ok bool) {
+	if i := IndexFunc(s, f); i >= 0 {
+		return s[i], true
+	}
+
+	return res, false
+}
+
 // Contains reports whether v is present in s.
 func ContainsEq[S ~[]T, T cmp.Eq[T]](s S, v T) bool { return IndexEq(s, v) >= 0 }
 
@@ -66,6 +110,34 @@ func RemapIndex[S ~[]E, E, T any](s S, f func(int, E) T) []T {
 	return res
 }
 
+// RemapErr is like [Remap], but f is able to fail. It stops on the first
+// error and returns it.
+func RemapErr[S ~[]E, E, T any](s S, f func(E) (T, error)) ([]T, error) {
+	res := make([]T, len(s))
+	for i, item := range s {
+		var err error
+		if res[i], err = f(item); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// RemapErrAll is like [RemapErr], but it doesn't stop on errors: it remaps
+// all items and returns all errors joined with [errors.Join]. Items which
+// failed are set to values, returned by f with error.
+func RemapErrAll[S ~[]E, E, T any](s S, f func(E) (T, error)) ([]T, error) {
+	var errs []error
+	res := make([]T, len(s))
+	for i, item := range s {
+		var err error
+		if res[i], err = f(item); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return res, errors.Join(errs...)
+}
+
 func Generate[T any](n int, f func(int) T) []T {
 	res := make([]T, n)
 	for i := 0; i < n; i++ {
@@ -75,24 +147,55 @@ func Generate[T any](n int, f func(int) T) []T {
 }
 
 // Possibiles возвращает все возможные сочетания элементов
+//
+// Empty slices in s are skipped. It's eager version of [Product], so for large
+// inputs prefer Product.
 func Possibles[S ~[]T, T any](s []S) (res []S) {
-	if len(s) == 0 {
-		return []S{}
-	}
-	if len(s[0]) == 0 {
-		return Possibles(s[1:])
+	nonEmpty := make([]S, 0, len(s))
+	for _, items := range s {
+		if len(items) > 0 {
+			nonEmpty = append(nonEmpty, items)
+		}
 	}
 
-	for _, elem := range s[0] {
-		morePossibilities := Possibles(s[1:])
-		if len(morePossibilities) == 0 {
-			res = append(res, S{elem})
+	return AppendSeq([]S{}, Product(nonEmpty))
+}
+
+// Product returns an iterator over cartesian product of s: each yielded tuple
+// contains one element from each slice of s, last element changes fastest.
+// Tuples are generated lazily, one at a time, and each tuple is a new slice,
+// so it's safe to keep it.
+//
+// If s is empty, or any of its slices is empty, sequence is empty.
+func Product[S ~[]T, T any](s []S) func(yield func(S) bool) {
+	return func(yield func(S) bool) {
+		if len(s) == 0 || ContainsFunc(s, func(items S) bool { return len(items) == 0 }) {
+			return
 		}
-		for _, nextItems := range morePossibilities {
-			res = append(res, append(S{elem}, nextItems...))
+
+		// odometer: idx[i] is a position in s[i]
+		idx := make([]int, len(s))
+		for {
+			tuple := make(S, len(s))
+			for i, j := range idx {
+				tuple[i] = s[i][j]
+			}
+			if !yield(tuple) {
+				return
+			}
+
+			i := len(idx) - 1
+			for ; i >= 0; i-- {
+				if idx[i]++; idx[i] < len(s[i]) {
+					break
+				}
+				idx[i] = 0
+			}
+			if i < 0 {
+				return
+			}
 		}
 	}
-	return res
 }
 
 // Concat returns a new slice concatenating the passed in slices.
@@ -112,9 +215,95 @@ func Concat[S ~[]E, E any](slices ...S) S {
 	return newslice
 }
 
+// MergeSorted merges already sorted slices into a new sorted slice. It's
+// O(n log k), where n is total count of elements and k is count of slices.
+func MergeSorted[S ~[]T, T cmp.Ordered](ss ...S) S { return MergeSortedFunc(cmp.Compare[T], ss...) }
+
+// MergeSortedFunc is like [MergeSorted], but uses cmp to compare elements. All
+// slices must be sorted by cmp. Merge is stable: equal elements keep order of
+// slices, in which they were passed.
+func MergeSortedFunc[S ~[]T, T any](cmp func(a, b T) int, ss ...S) S {
+	type cursor struct{ shard, pos int }
+
+	size := 0
+	h := make([]cursor, 0, len(ss))
+	for i, s := range ss {
+		if size += len(s); len(s) > 0 {
+			h = append(h, cursor{shard: i})
+		}
+	}
+
+	cursorCmp := func(a, b cursor) int {
+		if res := cmp(ss[a.shard][a.pos], ss[b.shard][b.pos]); res != 0 {
+			return res
+		}
+		return a.shard - b.shard
+	}
+	heapify(h, cursorCmp)
+
+	res := make(S, 0, size)
+	for len(h) > 0 {
+		c := &h[0]
+		res = append(res, ss[c.shard][c.pos])
+		if c.pos++; c.pos < len(ss[c.shard]) {
+			heapDown(h, 0, cursorCmp)
+		} else {
+			h, _ = heapPop(h, cursorCmp)
+		}
+	}
+
+	return res
+}
+
+// Flatten concatenates all slices of s into a new slice. Unlike [Concat], it
+// accepts existing slice of slices, without spreading it. Result is allocated
+// once.
+func Flatten[S ~[]E, E ~[]T, T any](s S) []T {
+	size := 0
+	for _, items := range s {
+		size += len(items)
+	}
+
+	res := make([]T, 0, size)
+	for _, items := range s {
+		res = append(res, items...)
+	}
+
+	return res
+}
+
+// FlattenDepth flattens nested []any slices up to depth levels (depth 1 is
+// the same as Flatten). Negative depth means unlimited. Elements of other
+// types, including typed slices, are kept as is, cause flattening them
+// requires reflection.
+func FlattenDepth(s []any, depth int) []any {
+	res := make([]any, 0, len(s))
+	for _, item := range s {
+		if nested, ok := item.([]any); ok && depth != 0 {
+			res = append(res, FlattenDepth(nested, depth-1)...)
+		} else {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
 // GentlyAppend добавляет
+//
+// It indexes existing items once, so it's O(len(s) + len(items)), unlike
+// [GentlyAppendFunc], which is O(len(s) * len(items)).
 func GentlyAppend[S ~[]T, T comparable](s S, items ...T) S {
-	return GentlyAppendFunc(s, func(a, b T) bool { return a == b }, items...)
+	existed := ToMap(s)
+	s = Grow(s, len(items))
+	for _, item := range items {
+		if _, ok := existed[item]; !ok {
+			existed[item] = struct{}{}
+			s = append(s, item)
+		}
+	}
+
+	return Clip(s)
 }
 
 func GentlyAppendEq[S ~[]T, T cmp.Eq[T]](s S, items ...T) S {
@@ -132,6 +321,76 @@ func GentlyAppendFunc[S ~[]T, T any](s S, f func(T, T) bool, items ...T) S {
 	return Clip(s)
 }
 
+// DeleteIndices removes elements at positions idx from s in a single pass,
+// returning the modified slice. Indices can be passed in any order, and
+// duplicates are ignored: all of them refer to positions in original s. It
+// panics, if any index is out of range.
+//
+// Removed tail of s is zeroed, so objects which it references can be garbage
+// collected.
+func DeleteIndices[S ~[]T, T any](s S, idx ...int) S {
+	if len(idx) == 0 {
+		return s
+	}
+
+	idx = Compact(Sort(Clone(idx)))
+	if idx[0] < 0 || idx[len(idx)-1] >= len(s) {
+		panic("index out of range")
+	}
+
+	i, next := idx[0], 0
+	for j := idx[0]; j < len(s); j++ {
+		if next < len(idx) && j == idx[next] {
+			next++
+			continue
+		}
+		s[i] = s[j]
+		i++
+	}
+
+	clear(s[i:])
+	return s[:i]
+}
+
+// RemoveAll removes all occurrences of v from s, returning the modified slice.
+func RemoveAll[S ~[]T, T comparable](s S, v T) S {
+	return DeleteFunc(s, func(item T) bool { return item == v })
+}
+
+// Uniq returns a new slice with duplicates of s removed, keeping the first
+// occurrence of each item in original order. Unlike [Compact], s is not
+// required to be sorted.
+func Uniq[S ~[]T, T comparable](s S) S { return UniqBy(s, func(item T) T { return item }) }
+
+// UniqBy is like [Uniq], but items are considered duplicates, if key returns
+// same value for them.
+func UniqBy[S ~[]T, T any, K comparable](s S, key func(T) K) S {
+	seen := make(map[K]struct{}, len(s))
+	res := make(S, 0, len(s))
+	for _, item := range s {
+		k := key(item)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			res = append(res, item)
+		}
+	}
+
+	return Clip(res)
+}
+
+// UniqFunc is like [Uniq], but uses eq to compare items. It's O(n²), so prefer
+// [UniqBy], if it's possible to extract comparable key from items.
+func UniqFunc[S ~[]T, T any](s S, eq func(a, b T) bool) S {
+	res := make(S, 0, len(s))
+	for _, item := range s {
+		if !ContainsFunc(res, func(existed T) bool { return eq(existed, item) }) {
+			res = append(res, item)
+		}
+	}
+
+	return Clip(res)
+}
+
 // Filter MODIFIES s, so only one possible way to use func is s = Filter(s, ...)
 func Filter[S ~[]T, T any](s S, f func(T) bool) S {
 	i := 0
@@ -145,6 +404,20 @@ func Filter[S ~[]T, T any](s S, f func(T) bool) S {
 	return Clip(s[:i])
 }
 
+// FilterMap remaps items with f and keeps only those, for which f returned
+// true. Unlike [Filter], it doesn't modify s, and unlike Filter + [Remap] pair
+// it makes a single pass without intermediate slice.
+func FilterMap[S ~[]E, E, T any](s S, f func(E) (T, bool)) []T {
+	var res []T
+	for _, item := range s {
+		if v, ok := f(item); ok {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
 // AddSorted inserts items into sorted slice. This could be useful for partly
 // ordered sets, but, if you need real set, use this type from other package.
 func AddSorted[S ~[]T, T cmp.Ordered](s S, items ...T) S {
@@ -154,11 +427,15 @@ func AddSorted[S ~[]T, T cmp.Ordered](s S, items ...T) S {
 // AddSorted inserts items of any type into sorted slice. This could be useful
 // for partly ordered sets, but, if you need real set, use this type from other
 // package.
+//
+// AddSortedFunc is not stable: order of equal elements is not preserved. Use
+// [AddSortedStableFunc] if it matters.
 func AddSortedFunc[S ~[]T, T any](s S, cmp func(elem, target T) int, items ...T) S {
 	if len(items) == 0 {
 		return s
 	} else if len(s) == 0 {
-		return SortFunc(items, cmp)
+		// cloning, cause items can be a slice of caller
+		return SortFunc(Clone(items), cmp)
 	}
 
 	s = Grow(s, len(items))
@@ -177,6 +454,114 @@ func AddSortedFunc[S ~[]T, T any](s S, cmp func(elem, target T) int, items ...T)
 	return s
 }
 
+// TopK returns k largest elements of s in descending order. It's O(n log k),
+// so it's much faster than sorting whole slice, if k is small. s is not
+// modified.
+func TopK[S ~[]T, T cmp.Ordered](s S, k int) S { return TopKFunc(s, k, cmp.Compare[T]) }
+
+// TopKFunc is like [TopK], but uses cmp to compare elements.
+func TopKFunc[S ~[]T, T any](s S, k int, cmp func(a, b T) int) S {
+	k = max(min(k, len(s)), 0)
+
+	// min-heap of k largest elements: root is the smallest of them, so it's
+	// the one to be replaced by larger element.
+	h := make(S, 0, k)
+	for _, item := range s {
+		if len(h) < k {
+			h = heapPush(h, item, cmp)
+		} else if k > 0 && cmp(item, h[0]) > 0 {
+			h[0] = item
+			heapDown(h, 0, cmp)
+		}
+	}
+
+	// popping from heap moves current minimum to the end, so after all pops
+	// slice is sorted in descending order.
+	for rest := h; len(rest) > 0; {
+		rest, _ = heapPop(rest, cmp)
+	}
+
+	return h
+}
+
+// MinMax returns minimal and maximal values of s in a single pass. If s is
+// empty, ok is false. Values are compared with [cmp.Compare], so NaN is
+// considered less than any other number.
+func MinMax[S ~[]T, T cmp.Ordered](s S) (min, max T, ok bool) {
+	return MinMaxFunc(s, cmp.Compare[T])
+}
+
+// MinMaxFunc is like [MinMax], but uses cmp to compare elements. If there is
+// more than one minimal or maximal element, the first one is returned.
+func MinMaxFunc[S ~[]T, T any](s S, cmp func(a, b T) int) (min, max T, ok bool) {
+	if len(s) == 0 {
+		return min, max, false
+	}
+
+	min, max = s[0], s[0]
+	for _, item := range s[1:] {
+		if cmp(item, min) < 0 {
+			min = item
+		} else if cmp(item, max) > 0 {
+			max = item
+		}
+	}
+
+	return min, max, true
+}
+
+// MinIndexFunc returns index of minimal element of s, using cmp to compare
+// elements, or -1 if s is empty. If there is more than one minimal element,
+// index of the first one is returned.
+func MinIndexFunc[S ~[]T, T any](s S, cmp func(a, b T) int) int {
+	return extremeIndex(s, func(a, b T) bool { return cmp(a, b) < 0 })
+}
+
+// MaxIndexFunc returns index of maximal element of s, using cmp to compare
+// elements, or -1 if s is empty. If there is more than one maximal element,
+// index of the first one is returned.
+func MaxIndexFunc[S ~[]T, T any](s S, cmp func(a, b T) int) int {
+	return extremeIndex(s, func(a, b T) bool { return cmp(a, b) > 0 })
+}
+
+func extremeIndex[S ~[]T, T any](s S, better func(a, b T) bool) int {
+	if len(s) == 0 {
+		return -1
+	}
+
+	res := 0
+	for i := 1; i < len(s); i++ {
+		if better(s[i], s[res]) {
+			res = i
+		}
+	}
+
+	return res
+}
+
+// AddSortedStableFunc is like [AddSortedFunc], but it's stable: each item is
+// placed after all equal elements of s, and equal items keep their relative
+// order. It returns a new slice, neither s nor items are modified.
+func AddSortedStableFunc[S ~[]T, T any](s S, cmp func(a, b T) int, items ...T) S {
+	sorted := SortStableFunc(Clone(items), cmp)
+
+	res := make(S, 0, len(s)+len(sorted))
+	i, j := 0, 0
+	for i < len(s) && j < len(sorted) {
+		// on equal elements, taking existing first
+		if cmp(sorted[j], s[i]) < 0 {
+			res = append(res, sorted[j])
+			j++
+		} else {
+			res = append(res, s[i])
+			i++
+		}
+	}
+
+	res = append(res, s[i:]...)
+	return append(res, sorted[j:]...)
+}
+
 func CountFunc[S ~[]E, E any](s S, f func(E) bool) (i int) {
 	for _, t := range s {
 		if f(t) {
@@ -186,6 +571,98 @@ func CountFunc[S ~[]E, E any](s S, f func(E) bool) (i int) {
 	return i
 }
 
+// Counts returns how many times each element occurs in s.
+func Counts[S ~[]T, T comparable](s S) map[T]int {
+	return CountsFunc(s, func(item T) T { return item })
+}
+
+// CountsFunc returns how many elements of s have each key.
+func CountsFunc[S ~[]T, T any, K comparable](s S, key func(T) K) map[K]int {
+	res := make(map[K]int)
+	for _, item := range s {
+		res[key(item)]++
+	}
+
+	return res
+}
+
+// EqualUnordered reports whether a and b contain the same elements the same
+// number of times, regardless of their order.
+func EqualUnordered[S ~[]T, T comparable](a, b S) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := Counts(a)
+	for _, item := range b {
+		if counts[item]--; counts[item] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// EqualUnorderedFunc is like [EqualUnordered], but uses eq to compare
+// elements. It's O(n²), since each element of a is matched with not yet
+// matched element of b. eq must be an equivalence relation, otherwise result
+// depends on the order of elements.
+func EqualUnorderedFunc[S1 ~[]E1, S2 ~[]E2, E1, E2 any](a S1, b S2, eq func(E1, E2) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	matched := make([]bool, len(b))
+	for _, x := range a {
+		i := 0
+		for ; i < len(b); i++ {
+			if !matched[i] && eq(x, b[i]) {
+				matched[i] = true
+				break
+			}
+		}
+		if i == len(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Duplicates returns values, which occur in s more than once. Each value is
+// returned once, in order of its first occurrence.
+func Duplicates[S ~[]T, T comparable](s S) []T {
+	counts := Counts(s)
+	var res []T
+	for _, item := range s {
+		if counts[item] > 1 {
+			res = append(res, item)
+			counts[item] = 0 // to return each value once
+		}
+	}
+
+	return res
+}
+
+// DuplicatesBy groups elements of s by key and returns only groups with more
+// than one element. Elements in each group keep their order. It's useful for
+// validation, when all conflicting entries must be reported.
+func DuplicatesBy[S ~[]T, T any, K comparable](s S, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range s {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+
+	for k, items := range groups {
+		if len(items) < 2 {
+			delete(groups, k)
+		}
+	}
+
+	return groups
+}
+
 func IsUnique[S ~[]E, E comparable](s S, v E) bool {
 	if i := Index(s, v); i >= 0 {
 		return Index(s[i+1:], v) < 0
@@ -220,6 +697,33 @@ func Repeat[T any](times int, s ...T) []T {
 	return res
 }
 
+// Split slices s into all sub-slices separated by sep and returns a slice of
+// them, like [strings.Split] does. Separators are not included into result,
+// so if s contains n separators, result contains n+1 sub-slices (some of them
+// can be empty).
+//
+// Sub-slices share memory with s, but they are clipped, so appending to them
+// doesn't modify s.
+func Split[S ~[]T, T comparable](s S, sep T) []S {
+	return SplitFunc(s, func(item T) bool { return item == sep })
+}
+
+// SplitFunc is like [Split], but any element, satisfying isSep, is considered
+// a separator. Unlike [strings.FieldsFunc], empty sub-slices are kept, so
+// positions of separators are not lost. You can drop them with [DeleteFunc].
+func SplitFunc[S ~[]T, T any](s S, isSep func(T) bool) []S {
+	var res []S
+	start := 0
+	for i, item := range s {
+		if isSep(item) {
+			res = append(res, s[start:i:i])
+			start = i + 1
+		}
+	}
+
+	return append(res, s[start:len(s):len(s)])
+}
+
 // Batch batches []E into [][]E in groups of size. The final chunk of []E will be
 // smaller than size if the input slice cannot be chunked evenly. It does not
 // make any copies of slice elements.
@@ -0,0 +1,946 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package slices_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/quenbyako/ext/cmp"
+	. "github.com/quenbyako/ext/slices"
+)
+
+func TestGentlyAppend(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		s     []int
+		items []int
+		want  []int
+	}{
+		{"empty", nil, nil, nil},
+		{"into empty", nil, []int{1, 2, 1}, []int{1, 2}},
+		{"existing", []int{1, 2, 3}, []int{3, 4, 1, 5, 4}, []int{1, 2, 3, 4, 5}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GentlyAppend(Clone(tt.s), tt.items...); !Equal(got, tt.want) {
+				t.Errorf("GentlyAppend(%v, %v) = %v, want %v", tt.s, tt.items, got, tt.want)
+			}
+
+			eq := func(a, b int) bool { return a == b }
+			if got := GentlyAppendFunc(Clone(tt.s), eq, tt.items...); !Equal(got, tt.want) {
+				t.Errorf("GentlyAppendFunc(%v, %v) = %v, want %v", tt.s, tt.items, got, tt.want)
+			}
+		})
+	}
+}
+
+func benchmarkGentlyAppend(b *testing.B, f func(s []int, items ...int) []int) {
+	const size = 10000
+	s := Generate(size, func(i int) int { return i * 2 })
+	items := Generate(size, func(i int) int { return i * 3 })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(Clone(s), items...)
+	}
+}
+
+func BenchmarkGentlyAppend10k(b *testing.B) {
+	benchmarkGentlyAppend(b, GentlyAppend[[]int])
+}
+
+func BenchmarkGentlyAppendFunc10k(b *testing.B) {
+	benchmarkGentlyAppend(b, func(s []int, items ...int) []int {
+		return GentlyAppendFunc(s, func(a, b int) bool { return a == b }, items...)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	s := []int{15, 20, 35, 40, 50}
+
+	for _, tt := range []struct {
+		p    float64
+		want float64
+	}{
+		{0, 15},
+		{25, 20},
+		{40, 29},
+		{50, 35},
+		{100, 50},
+	} {
+		if got := Percentile(s, tt.p); got != tt.want {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", s, tt.p, got, tt.want)
+		}
+	}
+
+	if got := Median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("Median = %v, want 2.5", got)
+	}
+	if !Equal(s, []int{15, 20, 35, 40, 50}) {
+		t.Errorf("Percentile modified input: %v", s)
+	}
+
+	// comparing quickselect with sorting on different inputs
+	for n := 1; n < 50; n++ {
+		s := Generate(n, func(i int) int { return (i * 7919) % 31 })
+		sorted := Sort(Clone(s))
+		for k := range sorted {
+			p := 50.0
+			if n > 1 {
+				p = 100 * float64(k) / float64(n-1)
+			}
+			if got, want := Percentile(s, p), float64(sorted[k]); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("Percentile(%v, %v) = %v, want %v", s, p, got, want)
+			}
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	if got := Sum(s); got != 40 {
+		t.Errorf("Sum = %v, want 40", got)
+	}
+	if got := Mean(s); got != 5 {
+		t.Errorf("Mean = %v, want 5", got)
+	}
+	if got := StdDev(s); got != 2 {
+		t.Errorf("StdDev = %v, want 2", got)
+	}
+	if got := Mean([]int{}); !math.IsNaN(got) {
+		t.Errorf("Mean(empty) = %v, want NaN", got)
+	}
+}
+
+func TestAddSortedStableFunc(t *testing.T) {
+	type item struct{ key, seq int }
+	cmpKey := func(a, b item) int { return a.key - b.key }
+
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for i := 0; i < 1000; i++ {
+		seq := 0
+		gen := func(n int) []item {
+			return Generate(n, func(int) item {
+				seq++
+				return item{key: rnd.IntN(10), seq: seq}
+			})
+		}
+
+		s := SortStableFunc(gen(rnd.IntN(20)), cmpKey)
+		items := gen(rnd.IntN(20))
+		origItems := Clone(items)
+
+		got := AddSortedStableFunc(s, cmpKey, items...)
+		if len(got) != len(s)+len(items) {
+			t.Fatalf("AddSortedStableFunc(%v, %v): got %v items, want %v", s, items, len(got), len(s)+len(items))
+		}
+		if !Equal(items, origItems) {
+			t.Fatalf("AddSortedStableFunc modified items: %v, want %v", items, origItems)
+		}
+
+		// elements of s are generated before items, so stable insertion keeps
+		// sequence numbers of equal keys increasing.
+		if !IsSortedFunc(got, func(a, b item) int {
+			if res := cmpKey(a, b); res != 0 {
+				return res
+			}
+			return a.seq - b.seq
+		}) {
+			t.Fatalf("AddSortedStableFunc(%v, %v) = %v: not sorted or not stable", s, items, got)
+		}
+
+		unstable := AddSortedFunc(Clone(s), cmpKey, items...)
+		if !IsSortedFunc(unstable, cmpKey) {
+			t.Fatalf("AddSortedFunc(%v, %v) = %v: not sorted", s, items, unstable)
+		}
+	}
+}
+
+func TestRemapErr(t *testing.T) {
+	errOdd := errors.New("odd")
+	half := func(i int) (int, error) {
+		if i%2 != 0 {
+			return -i, fmt.Errorf("%v: %w", i, errOdd)
+		}
+		return i / 2, nil
+	}
+
+	for _, tt := range []struct {
+		name    string
+		s       []int
+		want    []int
+		wantAll []int
+		errs    int
+	}{
+		{"empty", nil, []int{}, []int{}, 0},
+		{"no errors", []int{2, 4}, []int{1, 2}, []int{1, 2}, 0},
+		{"one error", []int{2, 3, 4}, nil, []int{1, -3, 2}, 1},
+		{"many errors", []int{1, 2, 3}, nil, []int{-1, 1, -3}, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemapErr(tt.s, half)
+			if !Equal(got, tt.want) || (err != nil) != (tt.errs > 0) {
+				t.Errorf("RemapErr(%v) = %v, %v, want %v", tt.s, got, err, tt.want)
+			}
+			if tt.errs > 0 && (got != nil || !errors.Is(err, errOdd)) {
+				t.Errorf("RemapErr(%v) must return nil and first error, got %v, %v", tt.s, got, err)
+			}
+
+			got, err = RemapErrAll(tt.s, half)
+			if !Equal(got, tt.wantAll) {
+				t.Errorf("RemapErrAll(%v) = %v, want %v", tt.s, got, tt.wantAll)
+			}
+			var errs []error
+			if err != nil {
+				errs = err.(interface{ Unwrap() []error }).Unwrap()
+			}
+			if len(errs) != tt.errs {
+				t.Errorf("RemapErrAll(%v) returned %v errors, want %v: %v", tt.s, len(errs), tt.errs, err)
+			}
+		})
+	}
+}
+
+func TestFilterMap(t *testing.T) {
+	parse := func(s string) (int, bool) {
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+
+	for _, tt := range []struct {
+		name string
+		s    []string
+		want []int
+	}{
+		{"empty", nil, nil},
+		{"all kept", []string{"1", "2"}, []int{1, 2}},
+		{"some dropped", []string{"1", "x", "3", ""}, []int{1, 3}},
+		{"all dropped", []string{"x", "y"}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := Clone(tt.s)
+			if got := FilterMap(tt.s, parse); !Equal(got, tt.want) {
+				t.Errorf("FilterMap(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+			if !Equal(tt.s, orig) {
+				t.Errorf("FilterMap modified input: %q, want %q", tt.s, orig)
+			}
+		})
+	}
+}
+
+func TestUniq(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []string
+		want []string
+	}{
+		{"empty", nil, nil},
+		{"unique", []string{"b", "a"}, []string{"b", "a"}},
+		{"unsorted duplicates", []string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+		{"all same", []string{"a", "a", "a"}, []string{"a"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := Clone(tt.s)
+			if got := Uniq(tt.s); !Equal(got, tt.want) {
+				t.Errorf("Uniq(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if got := UniqFunc(tt.s, func(a, b string) bool { return a == b }); !Equal(got, tt.want) {
+				t.Errorf("UniqFunc(%q) = %q, want %q", tt.s, got, tt.want)
+			}
+			if !Equal(tt.s, orig) {
+				t.Errorf("Uniq modified input: %q, want %q", tt.s, orig)
+			}
+		})
+	}
+
+	// first occurrence of each key is kept
+	s := []string{"apple", "avocado", "banana", "blueberry", "cherry"}
+	if got, want := UniqBy(s, func(s string) byte { return s[0] }), []string{"apple", "banana", "cherry"}; !Equal(got, want) {
+		t.Errorf("UniqBy(%q) = %q, want %q", s, got, want)
+	}
+}
+
+func TestSetOps(t *testing.T) {
+	eq := func(a, b int) bool { return a == b }
+
+	for _, tt := range []struct {
+		name                       string
+		a, b                       []int
+		intersect, union, subtract []int
+	}{
+		{"empty", nil, nil, nil, nil, nil},
+		{"empty b", []int{1, 2}, nil, nil, []int{1, 2}, []int{1, 2}},
+		{"disjoint", []int{1, 2}, []int{3}, nil, []int{1, 2, 3}, []int{1, 2}},
+		{"overlap", []int{3, 1, 2}, []int{2, 4, 3}, []int{3, 2}, []int{3, 1, 2, 4}, []int{1}},
+		{"duplicates", []int{1, 1, 2, 2}, []int{2, 2, 3}, []int{2}, []int{1, 2, 3}, []int{1}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Intersect(tt.a, tt.b); !Equal(got, tt.intersect) {
+				t.Errorf("Intersect(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.intersect)
+			}
+			if got := IntersectFunc(tt.a, tt.b, eq); !Equal(got, tt.intersect) {
+				t.Errorf("IntersectFunc(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.intersect)
+			}
+			if got := Union(tt.a, tt.b); !Equal(got, tt.union) {
+				t.Errorf("Union(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.union)
+			}
+			if got := UnionFunc(tt.a, tt.b, eq); !Equal(got, tt.union) {
+				t.Errorf("UnionFunc(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.union)
+			}
+			if got := Subtract(tt.a, tt.b); !Equal(got, tt.subtract) {
+				t.Errorf("Subtract(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.subtract)
+			}
+			if got := SubtractFunc(tt.a, tt.b, eq); !Equal(got, tt.subtract) {
+				t.Errorf("SubtractFunc(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.subtract)
+			}
+		})
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	s := Generate(100, func(i int) int { return i })
+
+	got := Shuffle(Clone(s), rand.NewPCG(1, 2))
+	if !EqualUnordered(got, s) {
+		t.Fatalf("Shuffle(%v) = %v: not a permutation", s, got)
+	}
+	if Equal(got, s) {
+		t.Errorf("Shuffle(%v) didn't change order", s)
+	}
+	if again := Shuffle(Clone(s), rand.NewPCG(1, 2)); !Equal(got, again) {
+		t.Errorf("Shuffle with same source: got %v and %v", got, again)
+	}
+
+	for _, s := range [][]int{nil, {1}} {
+		if got := Shuffle(Clone(s), nil); !Equal(got, s) {
+			t.Errorf("Shuffle(%v) = %v", s, got)
+		}
+	}
+}
+
+func TestSample(t *testing.T) {
+	s := Generate(20, func(i int) int { return i })
+
+	for _, tt := range []struct {
+		name string
+		n    int
+		want int
+	}{
+		{"negative", -1, 0},
+		{"zero", 0, 0},
+		{"some", 5, 5},
+		{"all", 20, 20},
+		{"more than len", 30, 20},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sample(s, tt.n, rand.NewPCG(1, 2))
+			if len(got) != tt.want {
+				t.Fatalf("Sample(%v) returned %v items, want %v", tt.n, len(got), tt.want)
+			}
+			if dups := Duplicates(got); len(dups) > 0 {
+				t.Errorf("Sample(%v) = %v: items selected more than once: %v", tt.n, got, dups)
+			}
+			if sub := Subtract(got, s); len(sub) > 0 {
+				t.Errorf("Sample(%v) = %v: unknown items %v", tt.n, got, sub)
+			}
+			if !Equal(s, Generate(20, func(i int) int { return i })) {
+				t.Errorf("Sample modified input: %v", s)
+			}
+		})
+	}
+
+	// every element must be selectable
+	seen := make(map[int]bool)
+	src := rand.NewPCG(3, 4)
+	for i := 0; i < 100; i++ {
+		for _, v := range Sample(s, 3, src) {
+			seen[v] = true
+		}
+	}
+	if len(seen) != len(s) {
+		t.Errorf("Sample selected only %v of %v items", len(seen), len(s))
+	}
+}
+
+func TestTopK(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		k    int
+		want []int
+	}{
+		{"empty", nil, 3, []int{}},
+		{"k is zero", []int{1, 2}, 0, []int{}},
+		{"k is negative", []int{1, 2}, -1, []int{}},
+		{"k < len", []int{5, 1, 9, 3, 7}, 2, []int{9, 7}},
+		{"k > len", []int{2, 3, 1}, 10, []int{3, 2, 1}},
+		{"duplicates", []int{4, 1, 4, 2, 4, 3}, 4, []int{4, 4, 4, 3}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := Clone(tt.s)
+			if got := TopK(tt.s, tt.k); !Equal(got, tt.want) {
+				t.Errorf("TopK(%v, %v) = %v, want %v", tt.s, tt.k, got, tt.want)
+			}
+			if !Equal(tt.s, orig) {
+				t.Errorf("TopK modified input: %v, want %v", tt.s, orig)
+			}
+		})
+	}
+
+	// comparing with sorting on different inputs
+	for n := 0; n < 30; n++ {
+		s := Generate(n, func(i int) int { return (i * 7919) % 13 })
+		sorted := SortFunc(Clone(s), func(a, b int) int { return b - a })
+		for k := 0; k <= n; k++ {
+			if got := TopKFunc(s, k, func(a, b int) int { return a - b }); !Equal(got, sorted[:k]) {
+				t.Fatalf("TopKFunc(%v, %v) = %v, want %v", s, k, got, sorted[:k])
+			}
+		}
+	}
+}
+
+func TestProduct(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		s         [][]int
+		product   [][]int
+		possibles [][]int
+	}{
+		{"no factors", nil, nil, nil},
+		{"one factor", [][]int{{1, 2}}, [][]int{{1}, {2}}, [][]int{{1}, {2}}},
+		{"empty factor", [][]int{{1, 2}, {}, {3}}, nil, [][]int{{1, 3}, {2, 3}}},
+		{"last changes fastest", [][]int{{1, 2}, {3, 4, 5}}, [][]int{
+			{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5},
+		}, [][]int{
+			{1, 3}, {1, 4}, {1, 5}, {2, 3}, {2, 4}, {2, 5},
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			equal := func(a, b [][]int) bool { return EqualFunc(a, b, Equal[[]int]) }
+
+			if got := Collect(Product(tt.s)); !equal(got, tt.product) {
+				t.Errorf("Product(%v) = %v, want %v", tt.s, got, tt.product)
+			}
+			if got := Possibles(tt.s); !equal(got, tt.possibles) {
+				t.Errorf("Possibles(%v) = %v, want %v", tt.s, got, tt.possibles)
+			}
+		})
+	}
+
+	// tuples are independent slices, and iteration stops on break
+	var got [][]int
+	Product([][]int{{1, 2}, {3, 4}})(func(tuple []int) bool {
+		got = append(got, tuple)
+		return len(got) < 2
+	})
+	got[0][0] = 100
+	if want := [][]int{{100, 3}, {1, 4}}; !EqualFunc(got, want, Equal[[]int]) {
+		t.Errorf("Product tuples share memory or iteration didn't stop: %v, want %v", got, want)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		vals []string
+		want string
+	}{
+		{"no values", nil, ""},
+		{"all zero", []string{"", ""}, ""},
+		{"first", []string{"a", "b"}, "a"},
+		{"skips zero", []string{"", "b", "c"}, "b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Coalesce(tt.vals...); got != tt.want {
+				t.Errorf("Coalesce(%q) = %q, want %q", tt.vals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFirstFunc(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+
+	for _, tt := range []struct {
+		name string
+		s    []int
+		want int
+		ok   bool
+	}{
+		{"empty", nil, 0, false},
+		{"not found", []int{1, 3}, 0, false},
+		{"first of many", []int{1, 4, 6}, 4, true},
+		{"zero value found", []int{1, 0}, 0, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := FirstFunc(tt.s, even); got != tt.want || ok != tt.ok {
+				t.Errorf("FirstFunc(%v) = %v, %v, want %v, %v", tt.s, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		s                  []float64
+		min, max           float64
+		ok                 bool
+		minIndex, maxIndex int
+	}{
+		{"empty", nil, 0, 0, false, -1, -1},
+		{"single", []float64{1}, 1, 1, true, 0, 0},
+		{"unsorted", []float64{3, 1, 4, 1, 5, 9, 2}, 1, 9, true, 1, 5},
+		{"descending", []float64{3, 2, 1}, 1, 3, true, 2, 0},
+		{"negative", []float64{-1, -5, -3}, -5, -1, true, 1, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if min, max, ok := MinMax(tt.s); min != tt.min || max != tt.max || ok != tt.ok {
+				t.Errorf("MinMax(%v) = %v, %v, %v, want %v, %v, %v", tt.s, min, max, ok, tt.min, tt.max, tt.ok)
+			}
+			if i := MinIndexFunc(tt.s, cmp.Compare[float64]); i != tt.minIndex {
+				t.Errorf("MinIndexFunc(%v) = %v, want %v", tt.s, i, tt.minIndex)
+			}
+			if i := MaxIndexFunc(tt.s, cmp.Compare[float64]); i != tt.maxIndex {
+				t.Errorf("MaxIndexFunc(%v) = %v, want %v", tt.s, i, tt.maxIndex)
+			}
+		})
+	}
+
+	if min, _, _ := MinMax([]float64{1, math.NaN()}); !math.IsNaN(min) {
+		t.Errorf("MinMax: NaN must be less than any number, got %v", min)
+	}
+
+	// first of equal elements is returned
+	type item struct{ key, seq int }
+	s := []item{{2, 0}, {1, 1}, {2, 2}, {1, 3}}
+	byKey := func(a, b item) int { return a.key - b.key }
+	if min, max, _ := MinMaxFunc(s, byKey); min.seq != 1 || max.seq != 0 {
+		t.Errorf("MinMaxFunc(%v) = %v, %v, want first equal elements", s, min, max)
+	}
+}
+
+func TestMergeSorted(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ss   [][]int
+		want []int
+	}{
+		{"no inputs", nil, []int{}},
+		{"empty inputs", [][]int{nil, {}}, []int{}},
+		{"one input", [][]int{{1, 2, 3}}, []int{1, 2, 3}},
+		{"unequal lengths", [][]int{{1, 5}, {}, {2, 3, 4, 6, 7}, {0}}, []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"ties", [][]int{{1, 2, 2}, {2, 3}, {1}}, []int{1, 1, 2, 2, 2, 3}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSorted(tt.ss...); !Equal(got, tt.want) {
+				t.Errorf("MergeSorted(%v) = %v, want %v", tt.ss, got, tt.want)
+			}
+		})
+	}
+
+	// merge is stable: equal elements keep order of slices
+	type item struct{ key, src int }
+	ss := [][]item{
+		{{1, 0}, {2, 0}, {2, 0}},
+		{{0, 1}, {2, 1}},
+		{{1, 2}, {2, 2}, {3, 2}},
+	}
+	got := MergeSortedFunc(func(a, b item) int { return a.key - b.key }, ss...)
+	want := []item{{0, 1}, {1, 0}, {1, 2}, {2, 0}, {2, 0}, {2, 1}, {2, 2}, {3, 2}}
+	if !Equal(got, want) {
+		t.Errorf("MergeSortedFunc(%v) = %v, want %v", ss, got, want)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	s := []string{"a", "b", "c"}
+
+	var got []string
+	Enumerate(s)(func(i int, v string) bool {
+		got = append(got, strconv.Itoa(i)+v)
+		return i < 1
+	})
+	if want := []string{"0a", "1b"}; !Equal(got, want) {
+		t.Errorf("Enumerate(%q) = %q, want %q", s, got, want)
+	}
+}
+
+func TestSeqAdapters(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+	double := func(i int) int { return i * 2 }
+	s := []int{1, 2, 3, 4, 5, 6}
+
+	for _, tt := range []struct {
+		name string
+		seq  func(yield func(int) bool)
+		want []int
+	}{
+		{"filter", FilterSeq(Values(s), even), []int{2, 4, 6}},
+		{"remap", RemapSeq(Values(s), double), []int{2, 4, 6, 8, 10, 12}},
+		{"take", TakeSeq(Values(s), 2), []int{1, 2}},
+		{"take zero", TakeSeq(Values(s), 0), nil},
+		{"take more", TakeSeq(Values(s), 10), s},
+		{"skip", SkipSeq(Values(s), 4), []int{5, 6}},
+		{"skip all", SkipSeq(Values(s), 10), nil},
+		{"skip negative", SkipSeq(Values(s), -1), s},
+		{"chain", TakeSeq(RemapSeq(FilterSeq(Values(s), even), double), 2), []int{4, 8}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Collect(tt.seq); !Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// source is stopped right after taken elements, and elements pass the
+	// whole chain one by one
+	var pulled []int
+	source := func(yield func(int) bool) {
+		for _, v := range s {
+			if pulled = append(pulled, v); !yield(v) {
+				return
+			}
+		}
+	}
+	if got := Collect(TakeSeq(FilterSeq(source, even), 2)); !Equal(got, []int{2, 4}) {
+		t.Errorf("got %v, want [2 4]", got)
+	}
+	if want := []int{1, 2, 3, 4}; !Equal(pulled, want) {
+		t.Errorf("source yielded %v, want %v", pulled, want)
+	}
+
+	// early stop of consumer stops every adapter
+	for name, seq := range map[string]func(yield func(int) bool){
+		"filter": FilterSeq(Values(s), even),
+		"remap":  RemapSeq(Values(s), double),
+		"take":   TakeSeq(Values(s), 5),
+		"skip":   SkipSeq(Values(s), 1),
+	} {
+		calls := 0
+		seq(func(int) bool { calls++; return false })
+		if calls != 1 {
+			t.Errorf("%v: yield called %v times after stop", name, calls)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    [][]int
+		want []int
+	}{
+		{"empty", nil, []int{}},
+		{"empty items", [][]int{nil, {}}, []int{}},
+		{"mixed", [][]int{{1, 2}, nil, {3}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Flatten(tt.s); !Equal(got, tt.want) {
+				t.Errorf("Flatten(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	s := []any{1, []any{2, []any{3, []any{4}}}, []int{5}}
+	for _, tt := range []struct {
+		depth int
+		want  string
+	}{
+		{0, "[1 [2 [3 [4]]] [5]]"},
+		{1, "[1 2 [3 [4]] [5]]"},
+		{2, "[1 2 3 [4] [5]]"},
+		{-1, "[1 2 3 4 [5]]"},
+	} {
+		if got := fmt.Sprint(FlattenDepth(s, tt.depth)); got != tt.want {
+			t.Errorf("FlattenDepth(%v, %v) = %v, want %v", s, tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	users := []user{{1, "alice"}, {2, "bob"}, {1, "carol"}}
+
+	got := Associate(users, func(u user) (string, int) { return u.name, u.id })
+	if want := map[string]int{"alice": 1, "bob": 2, "carol": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Associate(%v) = %v, want %v", users, got, want)
+	}
+
+	// last item wins on same key
+	byID := KeyBy(users, func(u user) int { return u.id })
+	if want := map[int]user{1: {1, "carol"}, 2: {2, "bob"}}; !reflect.DeepEqual(byID, want) {
+		t.Errorf("KeyBy(%v) = %v, want %v", users, byID, want)
+	}
+
+	if got := KeyBy([]user(nil), func(u user) int { return u.id }); got == nil || len(got) != 0 {
+		t.Errorf("KeyBy(nil) = %#v, want empty map", got)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		want [][]int
+	}{
+		{"empty", nil, [][]int{{}}},
+		{"no separators", []int{1, 2}, [][]int{{1, 2}}},
+		{"separators", []int{1, 0, 2, 3, 0, 4}, [][]int{{1}, {2, 3}, {4}}},
+		{"edge separators", []int{0, 1, 0}, [][]int{{}, {1}, {}}},
+		{"adjacent separators", []int{1, 0, 0, 2}, [][]int{{1}, {}, {2}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.s, 0); !EqualFunc(got, tt.want, Equal[[]int]) {
+				t.Errorf("Split(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	// appending to sub-slice doesn't modify source
+	s := []int{1, 0, 2}
+	parts := SplitFunc(s, func(i int) bool { return i == 0 })
+	_ = append(parts[0], 100)
+	if !Equal(s, []int{1, 0, 2}) {
+		t.Errorf("append to sub-slice modified source: %v", s)
+	}
+}
+
+func TestCounts(t *testing.T) {
+	s := []string{"a", "b", "a", "c", "a", "b"}
+	if got, want := Counts(s), map[string]int{"a": 3, "b": 2, "c": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Counts(%q) = %v, want %v", s, got, want)
+	}
+	if got := Counts([]string(nil)); len(got) != 0 {
+		t.Errorf("Counts(nil) = %v, want empty map", got)
+	}
+
+	words := []string{"go", "rust", "c", "java", "zig"}
+	if got, want := CountsFunc(words, func(s string) int { return len(s) }), map[int]int{1: 1, 2: 1, 3: 1, 4: 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountsFunc(%q) = %v, want %v", words, got, want)
+	}
+}
+
+func TestEqualUnordered(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		a, b []int
+		want bool
+	}{
+		{"empty", nil, []int{}, true},
+		{"same order", []int{1, 2}, []int{1, 2}, true},
+		{"other order", []int{1, 2, 3}, []int{3, 1, 2}, true},
+		{"different length", []int{1, 2}, []int{1, 2, 2}, false},
+		{"different counts", []int{1, 1, 2}, []int{1, 2, 2}, false},
+		{"different items", []int{1, 2}, []int{1, 3}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EqualUnordered(tt.a, tt.b); got != tt.want {
+				t.Errorf("EqualUnordered(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+
+			// comparing with elements of other type
+			b := Remap(tt.b, strconv.Itoa)
+			eq := func(i int, s string) bool { return strconv.Itoa(i) == s }
+			if got := EqualUnorderedFunc(tt.a, b, eq); got != tt.want {
+				t.Errorf("EqualUnorderedFunc(%v, %q) = %v, want %v", tt.a, b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		want []int
+	}{
+		{"empty", nil, []int{}},
+		{"single", []int{5}, []int{5}},
+		{"mixed", []int{1, 2, -3, 4}, []int{1, 3, 0, 4}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := Clone(tt.s)
+			if got := CumSum(tt.s); !Equal(got, tt.want) {
+				t.Errorf("CumSum(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+			if !Equal(tt.s, orig) {
+				t.Errorf("CumSum modified input: %v, want %v", tt.s, orig)
+			}
+		})
+	}
+
+	s := []int{3, 1, 4, 1, 5}
+	if got, want := CumFunc(s, func(acc, item int) int { return max(acc, item) }), []int{3, 3, 4, 4, 5}; !Equal(got, want) {
+		t.Errorf("CumFunc(%v, max) = %v, want %v", s, got, want)
+	}
+}
+
+func TestDeleteIndices(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		idx  []int
+		want []int
+	}{
+		{"no indices", []int{1, 2, 3}, nil, []int{1, 2, 3}},
+		{"first and last", []int{1, 2, 3, 4}, []int{0, 3}, []int{2, 3}},
+		{"unsorted", []int{1, 2, 3, 4, 5}, []int{3, 0, 2}, []int{2, 5}},
+		{"duplicates", []int{1, 2, 3}, []int{1, 1, 1}, []int{1, 3}},
+		{"all", []int{1, 2}, []int{1, 0}, []int{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Clone(tt.s)
+			got := DeleteIndices(s, tt.idx...)
+			if !Equal(got, tt.want) {
+				t.Errorf("DeleteIndices(%v, %v) = %v, want %v", tt.s, tt.idx, got, tt.want)
+			}
+			// tail is zeroed, so removed items could be collected
+			for i, v := range s[len(got):] {
+				if v != 0 {
+					t.Errorf("DeleteIndices(%v, %v): tail item %v is not zeroed: %v", tt.s, tt.idx, len(got)+i, v)
+				}
+			}
+		})
+	}
+
+	for _, idx := range [][]int{{-1}, {3}, {0, 5}} {
+		t.Run(fmt.Sprint("out of range ", idx), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DeleteIndices(%v) must panic", idx)
+				}
+			}()
+			DeleteIndices([]int{1, 2, 3}, idx...)
+		})
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	s := []int{1, 2, 1, 3, 1}
+	if got, want := RemoveAll(Clone(s), 1), []int{2, 3}; !Equal(got, want) {
+		t.Errorf("RemoveAll(%v, 1) = %v, want %v", s, got, want)
+	}
+	if got := RemoveAll(Clone(s), 5); !Equal(got, s) {
+		t.Errorf("RemoveAll(%v, 5) = %v, want %v", s, got, s)
+	}
+}
+
+func TestAt(t *testing.T) {
+	s := []string{"a", "b", "c"}
+
+	for _, tt := range []struct {
+		i    int
+		want string
+		ok   bool
+	}{
+		{-1, "", false},
+		{0, "a", true},
+		{2, "c", true},
+		{3, "", false},
+	} {
+		if got, ok := At(s, tt.i); got != tt.want || ok != tt.ok {
+			t.Errorf("At(%q, %v) = %q, %v, want %q, %v", s, tt.i, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got, ok := First(s); got != "a" || !ok {
+		t.Errorf("First(%q) = %q, %v", s, got, ok)
+	}
+	if got, ok := Last(s); got != "c" || !ok {
+		t.Errorf("Last(%q) = %q, %v", s, got, ok)
+	}
+	if _, ok := First([]string(nil)); ok {
+		t.Error("First(nil) must not be ok")
+	}
+	if _, ok := Last([]string(nil)); ok {
+		t.Error("Last(nil) must not be ok")
+	}
+}
+
+func TestTryInsertDelete(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		i    int
+		want []int
+		err  bool
+	}{
+		{"negative", -1, []int{1, 2}, true},
+		{"beginning", 0, []int{0, 1, 2}, false},
+		{"end", 2, []int{1, 2, 0}, false},
+		{"after end", 3, []int{1, 2}, true},
+	} {
+		t.Run("insert "+tt.name, func(t *testing.T) {
+			got, err := TryInsert([]int{1, 2}, tt.i, 0)
+			if !Equal(got, tt.want) || (err != nil) != tt.err {
+				t.Errorf("TryInsert(%v) = %v, %v, want %v", tt.i, got, err, tt.want)
+			}
+			if tt.err && !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("TryInsert(%v) error must be ErrOutOfRange, got %v", tt.i, err)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		name string
+		i, j int
+		want []int
+		err  bool
+	}{
+		{"negative", -1, 1, []int{1, 2, 3}, true},
+		{"reversed", 2, 1, []int{1, 2, 3}, true},
+		{"after end", 1, 4, []int{1, 2, 3}, true},
+		{"empty range", 1, 1, []int{1, 2, 3}, false},
+		{"middle", 1, 2, []int{1, 3}, false},
+		{"all", 0, 3, []int{}, false},
+	} {
+		t.Run("delete "+tt.name, func(t *testing.T) {
+			got, err := TryDelete([]int{1, 2, 3}, tt.i, tt.j)
+			if !Equal(got, tt.want) || (err != nil) != tt.err {
+				t.Errorf("TryDelete(%v, %v) = %v, %v, want %v", tt.i, tt.j, got, err, tt.want)
+			}
+			if tt.err && !errors.Is(err, ErrOutOfRange) {
+				t.Errorf("TryDelete(%v, %v) error must be ErrOutOfRange, got %v", tt.i, tt.j, err)
+			}
+		})
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		s    []int
+		want []int
+	}{
+		{"empty", nil, nil},
+		{"unique", []int{1, 2, 3}, nil},
+		{"order of first occurrence", []int{3, 1, 2, 1, 3, 3}, []int{3, 1}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Duplicates(tt.s); !Equal(got, tt.want) {
+				t.Errorf("Duplicates(%v) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+
+	emails := []string{"a@x.com", "b@y.com", "c@x.com", "d@z.com", "e@y.com"}
+	got := DuplicatesBy(emails, func(s string) string { return s[strings.Index(s, "@"):] })
+	want := map[string][]string{"@x.com": {"a@x.com", "c@x.com"}, "@y.com": {"b@y.com", "e@y.com"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DuplicatesBy(%q) = %q, want %q", emails, got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package slices
+
+import (
+	"math"
+
+	"github.com/quenbyako/ext/cmp"
+)
+
+// Sum returns sum of all elements of s. Note that for integers it can
+// overflow.
+func Sum[S ~[]T, T cmp.Number](s S) (res T) {
+	for _, v := range s {
+		res += v
+	}
+
+	return res
+}
+
+// CumSum returns running sums of s: i-th element of result is a sum of s[:i+1].
+func CumSum[S ~[]T, T cmp.Number](s S) S {
+	return CumFunc(s, func(acc, item T) T { return acc + item })
+}
+
+// CumFunc returns running aggregates of s: first element of result is s[0],
+// and each next one is f(previous aggregate, s[i]). s is not modified.
+func CumFunc[S ~[]T, T any](s S, f func(acc, item T) T) S {
+	res := make(S, len(s))
+	for i, item := range s {
+		if i == 0 {
+			res[i] = item
+		} else {
+			res[i] = f(res[i-1], item)
+		}
+	}
+
+	return res
+}
+
+// Mean returns arithmetic mean of s, or NaN if s is empty.
+func Mean[S ~[]T, T cmp.Number](s S) float64 {
+	if len(s) == 0 {
+		return math.NaN()
+	}
+
+	sum := 0.0
+	for _, v := range s {
+		sum += float64(v)
+	}
+
+	return sum / float64(len(s))
+}
+
+// StdDev returns population standard deviation of s, or NaN if s is empty.
+func StdDev[S ~[]T, T cmp.Number](s S) float64 {
+	mean := Mean(s)
+
+	sum := 0.0
+	for _, v := range s {
+		d := float64(v) - mean
+		sum += d * d
+	}
+
+	return math.Sqrt(sum / float64(len(s)))
+}
+
+// Median returns median of s, or NaN if s is empty. For even length, it's a
+// mean of two middle elements. See [Percentile] for details.
+func Median[S ~[]T, T cmp.Number](s S) float64 { return Percentile(s, 50) }
+
+// Percentile returns p-th percentile of s (p is from 0 to 100), using linear
+// interpolation between closest ranks (same as default method in numpy). It
+// returns NaN if s is empty, and panics, if p is out of range.
+//
+// Percentile uses quickselect, so it's O(n) on average and doesn't require
+// s to be sorted. s is not modified.
+func Percentile[S ~[]T, T cmp.Number](s S, p float64) float64 {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		panic("percentile out of range")
+	} else if len(s) == 0 {
+		return math.NaN()
+	}
+
+	values := Remap(s, func(v T) float64 { return float64(v) })
+	rank := p / 100 * float64(len(values)-1)
+	lo := int(rank)
+
+	quickselect(values, lo)
+	if frac := rank - float64(lo); frac > 0 {
+		// after quickselect, all elements after lo are not less than it, so
+		// next rank is the minimal of them.
+		next := Min(values[lo+1:])
+		return values[lo] + (next-values[lo])*frac
+	}
+
+	return values[lo]
+}
+
+// quickselect partially sorts s, so s[k] is k-th smallest element, all
+// elements before it are not greater, and all elements after are not less.
+func quickselect(s []float64, k int) {
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		// median of three as pivot to avoid worst case on sorted input
+		mid := lo + (hi-lo)/2
+		if s[mid] < s[lo] {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+		if s[hi] < s[lo] {
+			s[hi], s[lo] = s[lo], s[hi]
+		}
+		if s[hi] < s[mid] {
+			s[hi], s[mid] = s[mid], s[hi]
+		}
+		pivot := s[mid]
+
+		// hoare partition
+		i, j := lo, hi
+		for i <= j {
+			for s[i] < pivot {
+				i++
+			}
+			for s[j] > pivot {
+				j--
+			}
+			if i <= j {
+				s[i], s[j] = s[j], s[i]
+				i, j = i+1, j-1
+			}
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package slices
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Non-panicking accessors, useful in request handling code, where panic from
+// bad index is unacceptable.
+
+// ErrOutOfRange is returned by Try* functions, if index is out of range.
+var ErrOutOfRange = errors.New("index out of range")
+
+// At returns s[i]. If i is out of range, it returns zero value and false.
+func At[S ~[]T, T any](s S, i int) (res T, ok bool) {
+	if i < 0 || i >= len(s) {
+		return res, false
+	}
+
+	return s[i], true
+}
+
+// First returns the first element of s, or zero value and false, if s is
+// empty.
+func First[S ~[]T, T any](s S) (T, bool) { return At(s, 0) }
+
+// Last returns the last element of s, or zero value and false, if s is empty.
+func Last[S ~[]T, T any](s S) (T, bool) { return At(s, len(s)-1) }
+
+// TryInsert is like [Insert], but returns [ErrOutOfRange] instead of panic, if
+// i is out of range.
+func TryInsert[S ~[]T, T any](s S, i int, v ...T) (S, error) {
+	if i < 0 || i > len(s) {
+		return s, fmt.Errorf("%w: insert at %v, len %v", ErrOutOfRange, i, len(s))
+	}
+
+	return Insert(s, i, v...), nil
+}
+
+// TryDelete is like [Delete], but returns [ErrOutOfRange] instead of panic, if
+// s[i:j] is not a valid slice of s.
+func TryDelete[S ~[]T, T any](s S, i, j int) (S, error) {
+	if i < 0 || j < i || j > len(s) {
+		return s, fmt.Errorf("%w: delete [%v:%v], len %v", ErrOutOfRange, i, j, len(s))
+	}
+
+	return Delete(s, i, j), nil
+}
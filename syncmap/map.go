@@ -9,6 +9,8 @@ import (
 	"sync"
 )
 
+// Map is a typed wrapper of [sync.Map]. The zero Map is empty and ready for
+// use. A Map must not be copied after first use.
 type Map[K comparable, V any] struct {
 	m sync.Map
 }
@@ -39,3 +41,38 @@ func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
 func (m *Map[K, V]) Range(f func(key K, value V) bool) {
 	m.m.Range(func(key, value any) bool { return f(key.(K), value.(V)) })
 }
+
+// Swap swaps the value for a key and returns the previous value if any.
+func (m *Map[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	p, loaded := m.m.Swap(key, value)
+	if !loaded {
+		return previous, loaded
+	}
+	return p.(V), loaded
+}
+
+// CompareAndSwap swaps the old and new values for key if the value stored in
+// the map is equal to old. Like in [sync.Map], V must be comparable type,
+// otherwise it panics.
+func (m *Map[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return m.m.CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal to old.
+// Like in [sync.Map], V must be comparable type, otherwise it panics.
+func (m *Map[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return m.m.CompareAndDelete(key, old)
+}
+
+// Snapshot copies all entries into a plain map. Like Range, it doesn't
+// correspond to any consistent snapshot of the Map's contents, if map is
+// modified concurrently.
+func (m *Map[K, V]) Snapshot() map[K]V {
+	res := make(map[K]V)
+	m.Range(func(key K, value V) bool {
+		res[key] = value
+		return true
+	})
+
+	return res
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccountDetails contains account status, which is usually stored separately
+// from user data (e.g. in /etc/shadow).
+type AccountDetails struct {
+	// Expires is a date, when account will be (or was) disabled. Zero value
+	// means that account never expires.
+	Expires time.Time
+	// Locked reports that password authentication is locked for this account
+	// (password field starts with "!").
+	Locked bool
+	// PasswordChanged is a date of last password change. Zero value means
+	// that it's unknown.
+	PasswordChanged time.Time
+}
+
+// Expired reports whether account is expired at provided moment.
+func (d *AccountDetails) Expired(at time.Time) bool {
+	return !d.Expires.IsZero() && !at.Before(d.Expires)
+}
+
+// AccountDetailsProvider is an optional extension of [Provider], which is able
+// to return account status.
+type AccountDetailsProvider interface {
+	Provider
+
+	// AccountDetails returns details of user account. If the user cannot be
+	// found, the returned error is of type UnknownUserError.
+	AccountDetails(username string) (*AccountDetails, error)
+}
+
+// GetAccountDetails returns account details, if provider implements
+// [AccountDetailsProvider], otherwise it returns [errors.ErrUnsupported].
+func GetAccountDetails(p Provider, username string) (*AccountDetails, error) {
+	if p, ok := p.(AccountDetailsProvider); ok {
+		return p.AccountDetails(username)
+	}
+
+	return nil, errors.ErrUnsupported
+}
+
+var _ AccountDetailsProvider = OSProvider{}
+
+// AccountDetails reads /etc/shadow on every call, so usually it requires root
+// permissions.
+func (OSProvider) AccountDetails(username string) (*AccountDetails, error) {
+	shadow, err := os.ReadFile("/" + shadowFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if d, ok := parseShadow(shadow)[username]; ok {
+		return &d, nil
+	}
+
+	return nil, UnknownUserError(username)
+}
+
+// parseShadow parses shadow file. Format of each line is:
+//
+//	name:password:lastchg:min:max:warn:inactive:expire:reserved
+//
+// where lastchg and expire are days since unix epoch.
+func parseShadow(data []byte) map[string]AccountDetails {
+	res := make(map[string]AccountDetails)
+	for _, fields := range readColonFile(data, 9) {
+		res[fields[0]] = AccountDetails{
+			Expires:         parseEpochDays(fields[7]),
+			Locked:          strings.HasPrefix(fields[1], "!"),
+			PasswordChanged: parseEpochDays(fields[2]),
+		}
+	}
+
+	return res
+}
+
+func parseEpochDays(s string) time.Time {
+	// empty value means that field is disabled, 0 for lastchg means that
+	// password must be changed, so date is unknown as well.
+	if days, err := strconv.Atoi(s); err == nil && days > 0 {
+		// AddDate instead of Duration, which overflows after ~292 years
+		return time.Unix(0, 0).UTC().AddDate(0, 0, days)
+	}
+
+	return time.Time{}
+}
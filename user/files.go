@@ -0,0 +1,193 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/quenbyako/ext/slices"
+)
+
+const (
+	passwdFile = "etc/passwd"
+	groupFile  = "etc/group"
+	shadowFile = "etc/shadow"
+)
+
+// FilesProvider is a [Provider] which reads users and groups from passwd and
+// group files of some filesystem, e.g. mounted image layer. It allows you to
+// answer questions like "which uid does the app user in this image map to"
+// without running the container.
+//
+// FilesProvider reads files only once, in [NewFilesProvider].
+type FilesProvider struct {
+	// CurrentUID is an uid of user, returned by Current method. For container
+	// images it's usually a USER directive of image config. Default is root.
+	CurrentUID string
+
+	users  []*User
+	groups []fileGroup
+	// shadow is nil, if shadow file wasn't read, shadowErr contains reason.
+	shadow    map[string]AccountDetails
+	shadowErr error
+}
+
+type fileGroup struct {
+	Group
+	members []string
+}
+
+var _ AccountDetailsProvider = (*FilesProvider)(nil)
+
+// NewFilesProvider reads etc/passwd and etc/group from fsys. Note that paths
+// in fsys are unrooted, so if you want to read host files, pass
+// os.DirFS("/") (and better use [OSProvider]).
+//
+// etc/passwd must exist, etc/group is optional: if it's missing, users will
+// have only their primary groups. etc/shadow is optional too, if it's missing
+// or unreadable, AccountDetails method returns error of reading it.
+func NewFilesProvider(fsys fs.FS) (*FilesProvider, error) {
+	p := &FilesProvider{CurrentUID: "0"}
+
+	passwd, err := fs.ReadFile(fsys, passwdFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, fields := range readColonFile(passwd, 7) {
+		p.users = append(p.users, &User{
+			Username: fields[0],
+			Uid:      fields[2],
+			Gid:      fields[3],
+			Name:     strings.SplitN(fields[4], ",", 2)[0],
+			HomeDir:  fields[5],
+			Shell:    fields[6],
+			Gecos:    splitGecos(fields[4]),
+		})
+	}
+
+	group, err := fs.ReadFile(fsys, groupFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, fields := range readColonFile(group, 4) {
+		var members []string
+		if fields[3] != "" {
+			members = strings.Split(fields[3], ",")
+		}
+
+		p.groups = append(p.groups, fileGroup{
+			Group:   Group{Name: fields[0], Gid: fields[2]},
+			members: members,
+		})
+	}
+
+	if shadow, err := fs.ReadFile(fsys, shadowFile); err == nil {
+		p.shadow = parseShadow(shadow)
+	} else {
+		p.shadowErr = err
+	}
+
+	return p, nil
+}
+
+// readColonFile parses colon separated files like /etc/passwd. Lines with
+// wrong fields count, comments and NIS entries ("+" and "-" prefixed) are
+// skipped, like in stdlib implementation.
+func readColonFile(data []byte, fields int) (res [][]string) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == '+' || line[0] == '-' {
+			continue
+		}
+
+		if parts := strings.Split(line, ":"); len(parts) == fields {
+			res = append(res, parts)
+		}
+	}
+
+	return res
+}
+
+func splitGecos(gecos string) []string {
+	if gecos == "" {
+		return nil
+	}
+
+	return strings.Split(gecos, ",")
+}
+
+func (p *FilesProvider) Current() (*User, error) { return p.LookupID(p.CurrentUID) }
+
+func (p *FilesProvider) Lookup(username string) (*User, error) {
+	if i := slices.IndexFunc(p.users, func(u *User) bool { return u.Username == username }); i >= 0 {
+		return p.withGroups(p.users[i]), nil
+	}
+
+	return nil, UnknownUserError(username)
+}
+
+func (p *FilesProvider) LookupID(uid string) (*User, error) {
+	id, err := strconv.Atoi(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if i := slices.IndexFunc(p.users, func(u *User) bool { return u.Uid == uid }); i >= 0 {
+		return p.withGroups(p.users[i]), nil
+	}
+
+	return nil, UnknownUserIdError(id)
+}
+
+func (p *FilesProvider) LookupGroup(name string) (*Group, error) {
+	if i := slices.IndexFunc(p.groups, func(g fileGroup) bool { return g.Name == name }); i >= 0 {
+		g := p.groups[i].Group
+		return &g, nil
+	}
+
+	return nil, UnknownGroupError(name)
+}
+
+func (p *FilesProvider) LookupGroupID(gid string) (*Group, error) {
+	if i := slices.IndexFunc(p.groups, func(g fileGroup) bool { return g.Gid == gid }); i >= 0 {
+		g := p.groups[i].Group
+		return &g, nil
+	}
+
+	return nil, UnknownGroupIdError(gid)
+}
+
+// withGroups returns copy of user with filled group ids.
+func (p *FilesProvider) withGroups(u *User) *User {
+	res := u.Clone()
+	res.GroupIDs = []string{u.Gid}
+	for _, g := range p.groups {
+		if slices.Contains(g.members, u.Username) {
+			res.GroupIDs = append(res.GroupIDs, g.Gid)
+		}
+	}
+
+	return res.Normalize()
+}
+
+// AccountDetails returns account details from shadow file.
+func (p *FilesProvider) AccountDetails(username string) (*AccountDetails, error) {
+	if p.shadow == nil {
+		return nil, p.shadowErr
+	}
+
+	if d, ok := p.shadow[username]; ok {
+		return &d, nil
+	}
+
+	return nil, UnknownUserError(username)
+}
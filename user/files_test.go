@@ -0,0 +1,108 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFilesProvider(t *testing.T) {
+	t.Parallel()
+
+	p, err := NewFilesProvider(fstest.MapFS{
+		"etc/passwd": &fstest.MapFile{Data: []byte(`
+# comment
+root:x:0:0:root:/root:/bin/sh
+app:x:1000:1000:Application,,,:/home/app:/sbin/nologin
+broken:line
+`)},
+		"etc/group": &fstest.MapFile{Data: []byte(`
+root:x:0:
+app:x:1000:
+docker:x:999:app,other
+`)},
+		"etc/shadow": &fstest.MapFile{Data: []byte(`
+root:*:19000:0:99999:7:::
+app:!$6$salt$hash:19000:0:99999:7::19500:
+legacy:*:19000:0:99999:7::200000:
+`)},
+	})
+	if err != nil {
+		t.Fatalf("NewFilesProvider: %v", err)
+	}
+
+	u, err := p.Lookup("app")
+	noError(t, err)
+	assertEqual(t, &User{
+		Uid:      "1000",
+		Gid:      "1000",
+		Username: "app",
+		Name:     "Application",
+		HomeDir:  "/home/app",
+		Shell:    "/sbin/nologin",
+		Gecos:    []string{"Application", "", "", ""},
+		GroupIDs: []string{"1000", "999"},
+	}, u)
+
+	u, err = p.Current()
+	noError(t, err)
+	assertEqual(t, "root", u.Username)
+
+	g, err := p.LookupGroupID("999")
+	noError(t, err)
+	assertEqual(t, &Group{Gid: "999", Name: "docker"}, g)
+
+	d, err := GetAccountDetails(p, "app")
+	noError(t, err)
+	assertEqual(t, &AccountDetails{
+		Expires:         time.Date(2023, time.May, 23, 0, 0, 0, 0, time.UTC),
+		Locked:          true,
+		PasswordChanged: time.Date(2022, time.January, 8, 0, 0, 0, 0, time.UTC),
+	}, d)
+	assertEqual(t, true, d.Expired(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)))
+
+	d, err = p.AccountDetails("root")
+	noError(t, err)
+	assertEqual(t, &AccountDetails{
+		PasswordChanged: time.Date(2022, time.January, 8, 0, 0, 0, 0, time.UTC),
+	}, d)
+	assertEqual(t, false, d.Expired(time.Now()))
+
+	// returned users must not share memory with provider state
+	u, err = p.Lookup("app")
+	noError(t, err)
+	u.Gecos[0], u.GroupIDs[0] = "changed", "changed"
+	u, err = p.LookupID("1000")
+	noError(t, err)
+	assertEqual(t, []string{"Application", "", "", ""}, u.Gecos)
+	assertEqual(t, []string{"1000", "999"}, u.GroupIDs)
+
+	// large values must not overflow time.Duration
+	d, err = p.AccountDetails("legacy")
+	noError(t, err)
+	assertEqual(t, time.Date(2517, time.August, 1, 0, 0, 0, 0, time.UTC), d.Expires)
+
+	if _, err := p.Lookup("broken"); !errors.As(err, new(UnknownUserError)) {
+		t.Errorf("expected UnknownUserError, got %v", err)
+	}
+	if _, err := p.LookupID("5"); !errors.As(err, new(UnknownUserIdError)) {
+		t.Errorf("expected UnknownUserIdError, got %v", err)
+	}
+	if _, err := p.LookupGroup("wheel"); !errors.As(err, new(UnknownGroupError)) {
+		t.Errorf("expected UnknownGroupError, got %v", err)
+	}
+
+	if _, err := GetAccountDetails(&TestProvider{}, "app"); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+
+	if _, err := NewFilesProvider(fstest.MapFS{}); err == nil {
+		t.Errorf("expected error for missing passwd file")
+	}
+}
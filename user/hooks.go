@@ -0,0 +1,68 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"time"
+)
+
+// Hooks contains callbacks for each lookup, made through provider, wrapped by
+// [WithHooks]. Any of callbacks can be nil.
+//
+// method is a name of [Provider] method (e.g. "LookupGroup"), arg is an
+// argument of lookup (it's empty for "Current").
+type Hooks struct {
+	// Before is called right before lookup.
+	Before func(method, arg string)
+	// After is called right after lookup with its duration and returned error.
+	After func(method, arg string, took time.Duration, err error)
+}
+
+// WithHooks wraps provider, so each lookup is reported to hooks. It's useful
+// for counting and tracing identity lookups, e.g. in permission checks.
+func WithHooks(p Provider, hooks Hooks) Provider { return &hookedProvider{p: p, hooks: hooks} }
+
+type hookedProvider struct {
+	p     Provider
+	hooks Hooks
+}
+
+var _ Provider = (*hookedProvider)(nil)
+
+func (h *hookedProvider) Current() (*User, error) {
+	return observe(h.hooks, "Current", "", h.p.Current)
+}
+
+func (h *hookedProvider) Lookup(username string) (*User, error) {
+	return observe(h.hooks, "Lookup", username, func() (*User, error) { return h.p.Lookup(username) })
+}
+
+func (h *hookedProvider) LookupID(uid string) (*User, error) {
+	return observe(h.hooks, "LookupID", uid, func() (*User, error) { return h.p.LookupID(uid) })
+}
+
+func (h *hookedProvider) LookupGroup(name string) (*Group, error) {
+	return observe(h.hooks, "LookupGroup", name, func() (*Group, error) { return h.p.LookupGroup(name) })
+}
+
+func (h *hookedProvider) LookupGroupID(gid string) (*Group, error) {
+	return observe(h.hooks, "LookupGroupID", gid, func() (*Group, error) { return h.p.LookupGroupID(gid) })
+}
+
+func observe[T any](hooks Hooks, method, arg string, lookup func() (T, error)) (T, error) {
+	if hooks.Before != nil {
+		hooks.Before(method, arg)
+	}
+
+	start := time.Now()
+	res, err := lookup()
+
+	if hooks.After != nil {
+		hooks.After(method, arg, time.Since(start), err)
+	}
+
+	return res, err
+}
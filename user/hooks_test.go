@@ -0,0 +1,48 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithHooks(t *testing.T) {
+	t.Parallel()
+
+	var before, after []string
+	var errs []error
+
+	p := WithHooks(&TestProvider{
+		CurrentID: 10,
+		Users:     map[int]*User{10: {Username: "someuser"}},
+		Groups:    map[int]string{10: "somegroup"},
+	}, Hooks{
+		Before: func(method, arg string) { before = append(before, method+"("+arg+")") },
+		After: func(method, arg string, took time.Duration, err error) {
+			after = append(after, method+"("+arg+")")
+			errs = append(errs, err)
+		},
+	})
+
+	_, err := p.Current()
+	noError(t, err)
+	_, err = p.LookupGroup("somegroup")
+	noError(t, err)
+	_, err = p.Lookup("nobody")
+
+	want := []string{"Current()", "LookupGroup(somegroup)", "Lookup(nobody)"}
+	assertEqual(t, want, before)
+	assertEqual(t, want, after)
+	if e := UnknownUserError(""); !errors.As(errs[2], &e) || !errors.Is(err, errs[2]) {
+		t.Errorf("expected UnknownUserError to be reported, got %v", errs[2])
+	}
+
+	// nil hooks are allowed
+	_, err = WithHooks(OSProvider{}, Hooks{}).Current()
+	noError(t, err)
+}
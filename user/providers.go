@@ -8,9 +8,10 @@ package user
 import (
 	"errors"
 	"fmt"
-	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/quenbyako/ext/slices"
 )
 
 // CheckGroupExists is a wrapper for LookupGroup which also returns hinter error
@@ -38,8 +39,8 @@ func AssertUsersInGroup(p Provider, groups map[string][]string) (errs error) { /
 		for _, user := range users {
 			if u, err := p.Lookup(user); err != nil {
 				errs = errors.Join(errs, err)
-			} else if !slices.Contains(u.GroupIDs, g.Gid) {
-				errs = errors.Join(err, ErrUserNotInGroup{User: user, ExpectedGroup: group})
+			} else if !u.IsMemberOf(g.Gid) {
+				errs = errors.Join(errs, ErrUserNotInGroup{User: user, ExpectedGroup: group})
 			}
 		}
 	}
@@ -57,8 +58,8 @@ func AssertCurrentUserInGroups(p Provider, groups ...string) (errs error) { //co
 	for _, group := range groups {
 		if g, err := GetGroupWithHint(p, group); err != nil {
 			errs = errors.Join(errs, err)
-		} else if !slices.Contains(u.GroupIDs, g.Gid) {
-			errs = errors.Join(err, ErrUserNotInGroup{User: u.Username, ExpectedGroup: group})
+		} else if !u.IsMemberOf(g.Gid) {
+			errs = errors.Join(errs, ErrUserNotInGroup{User: u.Username, ExpectedGroup: group})
 		}
 	}
 
@@ -103,7 +104,7 @@ func (p *TestProvider) Current() (*User, error) {
 func (p *TestProvider) Lookup(username string) (*User, error) {
 	for _, u := range p.Users {
 		if u.Username == username {
-			return u, nil
+			return u.Clone().Normalize(), nil
 		}
 	}
 	return nil, UnknownUserError(username)
@@ -120,10 +121,12 @@ func (p *TestProvider) LookupID(uid string) (*User, error) {
 		return nil, UnknownUserIdError(id)
 	}
 
+	// users are copied, so concurrent lookups don't modify provider state
+	u = u.Clone()
 	// if you didn't provide uid in users, this line will do it for you 😘
 	u.Uid = uid
 
-	return u, nil
+	return u.Normalize(), nil
 }
 
 func (p *TestProvider) LookupGroup(name string) (*Group, error) {
@@ -185,6 +188,41 @@ func ResolveHomedir(users Provider, path string) (string, error) {
 	return u.HomeDir + path[len("~"):], nil
 }
 
+// Hinter is implemented by errors, which know how user can fix them. Each hint
+// is a single shell command or a short instruction, so CLI tools can print
+// them as is.
+type Hinter interface {
+	error
+	Hints() []string
+}
+
+var (
+	_ Hinter = ErrGroupNotExist("")
+	_ Hinter = ErrUserNotInGroup{}
+)
+
+// Hints collects hints from all [Hinter] errors in err tree (including errors
+// joined with [errors.Join]). Duplicated hints are returned only once.
+func Hints(err error) (hints []string) {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case Hinter:
+		return e.Hints()
+	case interface{ Unwrap() []error }:
+		for _, err := range e.Unwrap() {
+			hints = slices.GentlyAppend(hints, Hints(err)...)
+		}
+		return hints
+	default:
+		return Hints(errors.Unwrap(err))
+	}
+}
+
+// hintRelogin is required after any group membership change: group list of
+// running session is fixed on login.
+const hintRelogin = "log out and back in, so group membership changes are applied"
+
 // ErrGroupNotExist shows that group is not exist in the system or in the
 // provider. It extends stdlib [os/user.UnknownGroupError]
 type ErrGroupNotExist string
@@ -192,6 +230,15 @@ type ErrGroupNotExist string
 func (e ErrGroupNotExist) Error() string { return e.Unwrap().Error() }
 func (e ErrGroupNotExist) Unwrap() error { return UnknownGroupError(e) }
 
+// Hints proposes to create missing group and add current user to it.
+func (e ErrGroupNotExist) Hints() []string {
+	return []string{
+		fmt.Sprintf("sudo groupadd %v", string(e)),
+		fmt.Sprintf("sudo usermod -aG %v \"$USER\"", string(e)),
+		hintRelogin,
+	}
+}
+
 type ErrUserNotInGroup struct {
 	User          string
 	ExpectedGroup string
@@ -200,3 +247,11 @@ type ErrUserNotInGroup struct {
 func (e ErrUserNotInGroup) Error() string {
 	return fmt.Sprintf("user %q expected to be in %q group", e.User, e.ExpectedGroup)
 }
+
+// Hints proposes to add user to expected group.
+func (e ErrUserNotInGroup) Hints() []string {
+	return []string{
+		fmt.Sprintf("sudo usermod -aG %v %v", e.ExpectedGroup, e.User),
+		hintRelogin,
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"errors"
+	"testing"
+)
+
+func testProvider() *TestProvider {
+	return &TestProvider{
+		CurrentID: 1,
+		Users: map[int]*User{
+			1: {Username: "alice", GroupIDs: []string{"30"}},
+			2: {Username: "bob"},
+		},
+		Groups: map[int]string{10: "wheel", 20: "docker", 30: "users"},
+	}
+}
+
+func TestAssertUsersInGroup(t *testing.T) {
+	t.Parallel()
+
+	errs := AssertUsersInGroup(testProvider(), map[string][]string{
+		"wheel": {"alice", "bob"},
+		"users": {"alice"},
+	})
+
+	for _, want := range []error{
+		ErrUserNotInGroup{User: "alice", ExpectedGroup: "wheel"},
+		ErrUserNotInGroup{User: "bob", ExpectedGroup: "wheel"},
+	} {
+		if !errors.Is(errs, want) {
+			t.Errorf("error %q is missing in %q", want, errs)
+		}
+	}
+	if e := (ErrUserNotInGroup{User: "alice", ExpectedGroup: "users"}); errors.Is(errs, e) {
+		t.Errorf("unexpected error %q", e)
+	}
+}
+
+func TestAssertCurrentUserInGroups(t *testing.T) {
+	t.Parallel()
+
+	errs := AssertCurrentUserInGroups(testProvider(), "wheel", "docker", "users")
+
+	for _, want := range []error{
+		ErrUserNotInGroup{User: "alice", ExpectedGroup: "wheel"},
+		ErrUserNotInGroup{User: "alice", ExpectedGroup: "docker"},
+	} {
+		if !errors.Is(errs, want) {
+			t.Errorf("error %q is missing in %q", want, errs)
+		}
+	}
+
+	if err := AssertCurrentUserInGroups(testProvider(), "users"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
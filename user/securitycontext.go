@@ -0,0 +1,48 @@
+// Copyright (c) 2020-2024 Richard Cooper
+//
+// This file is a part of quenbyako/ext package.
+// See https://github.com/quenbyako/ext/blob/master/LICENSE for details
+
+package user
+
+import (
+	"strconv"
+)
+
+// FromSecurityContext creates user from kubernetes pod (or container) security
+// context fields, so you can pass it directly to permission checks, like
+// [github.com/quenbyako/ext/fs.CheckFileAbleToWrite].
+//
+// If runAsUser or runAsGroup is nil, container runtime takes them from image,
+// which is unknown here, so function assumes root (uid and gid 0), as most
+// common default. fsGroup, like in kubernetes, is added to supplemental groups
+// of the user.
+//
+// Returned user has only Uid, Gid and GroupIDs fields filled, GroupIDs are
+// sorted and contain primary group.
+func FromSecurityContext(runAsUser, runAsGroup *int64, supplementalGroups []int64, fsGroup *int64) *User {
+	uid, gid := idOrRoot(runAsUser), idOrRoot(runAsGroup)
+
+	groups := make([]string, 0, len(supplementalGroups)+2)
+	groups = append(groups, gid)
+	for _, g := range supplementalGroups {
+		groups = append(groups, strconv.FormatInt(g, 10))
+	}
+	if fsGroup != nil {
+		groups = append(groups, strconv.FormatInt(*fsGroup, 10))
+	}
+
+	return (&User{
+		Uid:      uid,
+		Gid:      gid,
+		GroupIDs: groups,
+	}).Normalize()
+}
+
+func idOrRoot(id *int64) string {
+	if id == nil {
+		return "0"
+	}
+
+	return strconv.FormatInt(*id, 10)
+}
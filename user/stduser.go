@@ -28,8 +28,13 @@
 package user
 
 import (
+	"os"
 	stduser "os/user"
 	"sort"
+	"sync"
+	"time"
+
+	"github.com/quenbyako/ext/slices"
 )
 
 // User represents a user account.
@@ -55,11 +60,22 @@ type User struct {
 	Name string
 	// HomeDir is the path to the user's home directory (if they have one).
 	HomeDir string
+	// Shell is the user's login shell. It's an additional field unlike in
+	// stduser. On POSIX systems it's taken from passwd file, so it can be
+	// empty for users from other sources (e.g. LDAP).
+	Shell string
+	// Gecos is the full list of GECOS field entries (usually full name, room
+	// number, work phone, home phone and other info). It's an additional field
+	// unlike in stduser, and like Shell, it can be empty for users which are
+	// not listed in passwd file.
+	Gecos []string
 	// GroupIDs is an additional field unlike in stduser, cause standard library
 	// can't get groups from custom provider/resource.
 	//
 	// GroupIDs expected to be sorted for easier searching. So you can use
-	// binary search
+	// binary search (or just [User.IsMemberOf]). All constructors of this
+	// package guarantee it, if you fill this field manually, call
+	// [User.Normalize] after.
 	GroupIDs []string
 }
 
@@ -81,14 +97,42 @@ func Current() (*User, error) {
 		return nil, err
 	}
 
-	return &User{
-		Uid:      u.Uid,
-		Gid:      u.Gid,
-		Username: u.Username,
-		Name:     u.Name,
-		HomeDir:  u.HomeDir,
-		GroupIDs: groups,
-	}, nil
+	return fromStdUser(u, groups), nil
+}
+
+var currentCache struct {
+	sync.Mutex
+	user    *User
+	fetched time.Time
+}
+
+// CurrentCached works like [Current], but refreshes user data not more often than
+// once per maxAge. It's designed for high-frequency code paths (e.g.
+// per-request permission checks), where getgroups syscall on every call is
+// noticeable.
+//
+// Trade-off: if user will be added or removed from some group, CurrentCached
+// will return stale [User.GroupIDs] up to maxAge. Note that process
+// credentials can be changed in runtime (e.g. with [syscall.Setuid]), and
+// neither this cache nor stdlib [os/user.Current] track it, so returned user
+// may be stale after such change. If you need exact group membership (e.g.
+// right before privileged operation), use [Current].
+//
+// Returned value is a deep copy, so it's safe to modify it.
+func CurrentCached(maxAge time.Duration) (*User, error) {
+	currentCache.Lock()
+	defer currentCache.Unlock()
+
+	if currentCache.user == nil || time.Since(currentCache.fetched) > maxAge {
+		u, err := Current()
+		if err != nil {
+			return nil, err
+		}
+
+		currentCache.user, currentCache.fetched = u, time.Now()
+	}
+
+	return currentCache.user.Clone(), nil
 }
 
 // Lookup looks up a user by username. If the user cannot be found, the returned
@@ -102,16 +146,8 @@ func Lookup(username string) (*User, error) {
 	if err != nil {
 		return nil, err
 	}
-	sort.Strings(groups)
 
-	return &User{
-		Uid:      u.Uid,
-		Gid:      u.Gid,
-		Username: u.Username,
-		Name:     u.Name,
-		HomeDir:  u.HomeDir,
-		GroupIDs: groups,
-	}, nil
+	return fromStdUser(u, groups), nil
 }
 
 // LookupId looks up a user by userid. If the user cannot be found, the returned
@@ -126,14 +162,85 @@ func LookupId(uid string) (*User, error) {
 		return nil, err
 	}
 
-	return &User{
+	return fromStdUser(u, groups), nil
+}
+
+func fromStdUser(u *stduser.User, groups []string) *User {
+	res := &User{
 		Uid:      u.Uid,
 		Gid:      u.Gid,
 		Username: u.Username,
 		Name:     u.Name,
 		HomeDir:  u.HomeDir,
 		GroupIDs: groups,
-	}, nil
+	}
+	// stdlib doesn't expose shell and gecos, so trying to find them in passwd
+	// by ourselves. Any error here is not critical.
+	if fields, ok := passwdEntry(res.Username); ok && fields[2] == res.Uid {
+		res.Gecos, res.Shell = splitGecos(fields[4]), fields[6]
+	}
+
+	return res.Normalize()
+}
+
+// passwdMaxAge limits how often passwd file is reread to fill [User.Shell] and
+// [User.Gecos] in lookups, so changes of passwd file are visible in lookups
+// not later than in a minute.
+const passwdMaxAge = time.Minute
+
+var passwdCache struct {
+	sync.Mutex
+	entries map[string][]string // username to passwd fields
+	fetched time.Time
+}
+
+// passwdEntry returns passwd fields of user. File is parsed not more often
+// than once per passwdMaxAge, so lookups don't read it every time. If file is
+// missing or unreadable, there are no entries until next refresh.
+func passwdEntry(username string) ([]string, bool) {
+	passwdCache.Lock()
+	defer passwdCache.Unlock()
+
+	if passwdCache.entries == nil || time.Since(passwdCache.fetched) > passwdMaxAge {
+		passwdCache.entries = make(map[string][]string)
+		if passwd, err := os.ReadFile("/" + passwdFile); err == nil {
+			for _, fields := range readColonFile(passwd, 7) {
+				if _, ok := passwdCache.entries[fields[0]]; !ok {
+					passwdCache.entries[fields[0]] = fields
+				}
+			}
+		}
+		passwdCache.fetched = time.Now()
+	}
+
+	fields, ok := passwdCache.entries[username]
+	return fields, ok
+}
+
+// Normalize sorts [User.GroupIDs] and removes duplicates from it, so binary
+// search over this field becomes valid. It modifies u in place and returns it
+// back for convenience.
+func (u *User) Normalize() *User {
+	sort.Strings(u.GroupIDs)
+	u.GroupIDs = slices.Compact(u.GroupIDs)
+
+	return u
+}
+
+// Clone returns a deep copy of u, so modifying it doesn't affect u.
+func (u *User) Clone() *User {
+	c := *u
+	c.Gecos = slices.Clone(u.Gecos)
+	c.GroupIDs = slices.Clone(u.GroupIDs)
+
+	return &c
+}
+
+// IsMemberOf reports whether user is a member of group with provided gid. It
+// expects [User.GroupIDs] to be sorted (see [User.Normalize]).
+func (u *User) IsMemberOf(gid string) bool {
+	_, ok := slices.BinarySearch(u.GroupIDs, gid)
+	return ok
 }
 
 // GroupIds returns the list of group IDs that the user is a member of.
@@ -8,7 +8,9 @@ package user
 import (
 	"os"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/quenbyako/ext/slices"
 )
@@ -187,3 +189,181 @@ func assertEqual[T any](t *testing.T, want, got T) {
 		t.FailNow()
 	}
 }
+
+func TestUserNormalize(t *testing.T) {
+	t.Parallel()
+
+	u := (&User{GroupIDs: []string{"20", "100", "0", "20", "100"}}).Normalize()
+	assertEqual(t, []string{"0", "100", "20"}, u.GroupIDs)
+
+	for _, tt := range []struct {
+		gid  string
+		want bool
+	}{
+		{"0", true},
+		{"20", true},
+		{"100", true},
+		{"10", false},
+		{"", false},
+	} {
+		if got := u.IsMemberOf(tt.gid); got != tt.want {
+			t.Errorf("IsMemberOf(%q) = %v, want %v", tt.gid, got, tt.want)
+		}
+	}
+}
+
+func TestHints(t *testing.T) {
+	t.Parallel()
+
+	p := &TestProvider{
+		CurrentID: 1000,
+		Users: map[int]*User{
+			1000: {Username: "alice", GroupIDs: []string{"1000"}},
+		},
+		Groups: map[int]string{
+			1000: "alice",
+			999:  "docker",
+		},
+	}
+
+	err := AssertCurrentUserInGroups(p, "docker", "wheel")
+	assertEqual(t, []string{
+		"sudo usermod -aG docker alice",
+		"log out and back in, so group membership changes are applied",
+		"sudo groupadd wheel",
+		`sudo usermod -aG wheel "$USER"`,
+	}, Hints(err))
+
+	assertEqual(t, []string(nil), Hints(nil))
+	assertEqual(t, []string(nil), Hints(os.ErrNotExist))
+}
+
+func TestCurrentCached(t *testing.T) {
+	want, err := Current()
+	if err != nil {
+		t.Fatalf("Current: %v", err)
+	}
+
+	got, err := CurrentCached(time.Hour)
+	if err != nil {
+		t.Fatalf("CurrentCached: %v", err)
+	}
+	compare(t, want, got)
+	assertEqual(t, want.GroupIDs, got.GroupIDs)
+
+	// modifying returned value must not affect cache
+	got.GroupIDs = append(got.GroupIDs[:0], "not_a_group")
+	if len(got.Gecos) > 0 {
+		got.Gecos[0] = "not_a_name"
+	}
+	got, err = CurrentCached(time.Hour)
+	if err != nil {
+		t.Fatalf("CurrentCached: %v", err)
+	}
+	assertEqual(t, want.GroupIDs, got.GroupIDs)
+	assertEqual(t, want.Gecos, got.Gecos)
+
+	// cached user is returned until it's older than maxAge, even if it's stale
+	setCurrentCache := func(u *User, age time.Duration) {
+		currentCache.Lock()
+		defer currentCache.Unlock()
+		currentCache.user, currentCache.fetched = u, time.Now().Add(-age)
+	}
+	stale := &User{Uid: "-1", Username: "stale"}
+
+	setCurrentCache(stale, 30*time.Second)
+	got, err = CurrentCached(time.Minute)
+	if err != nil {
+		t.Fatalf("CurrentCached: %v", err)
+	}
+	assertEqual(t, stale, got)
+
+	setCurrentCache(stale, 2*time.Minute)
+	got, err = CurrentCached(time.Minute)
+	if err != nil {
+		t.Fatalf("CurrentCached: %v", err)
+	}
+	compare(t, want, got)
+	if age := time.Since(currentCache.fetched); age > time.Minute {
+		t.Errorf("cache must be refreshed, got age %v", age)
+	}
+}
+
+func TestUser_Clone(t *testing.T) {
+	t.Parallel()
+
+	u := &User{Username: "alice", Gecos: []string{"Alice"}, GroupIDs: []string{"1", "2"}}
+	c := u.Clone()
+	c.Gecos[0], c.GroupIDs[0] = "Bob", "3"
+
+	assertEqual(t, []string{"Alice"}, u.Gecos)
+	assertEqual(t, []string{"1", "2"}, u.GroupIDs)
+}
+
+func TestFromSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	id := func(i int64) *int64 { return &i }
+
+	for _, tt := range []struct {
+		name         string
+		user, group  *int64
+		supplemental []int64
+		fsGroup      *int64
+		want         *User
+	}{{
+		name: "empty",
+		want: &User{Uid: "0", Gid: "0", GroupIDs: []string{"0"}},
+	}, {
+		name:         "full",
+		user:         id(1000),
+		group:        id(3000),
+		supplemental: []int64{4000, 3000},
+		fsGroup:      id(2000),
+		want:         &User{Uid: "1000", Gid: "3000", GroupIDs: []string{"2000", "3000", "4000"}},
+	}} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromSecurityContext(tt.user, tt.group, tt.supplemental, tt.fsGroup)
+			assertEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestTestProvider_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	groups := []string{"3", "1", "2", "1"}
+	p := &TestProvider{
+		Users: map[int]*User{
+			10: {Username: "alice", GroupIDs: groups},
+		},
+	}
+
+	// t.FailNow can't be called from spawned goroutines, so results are
+	// checked after all lookups are done.
+	users, errs := make([]*User, 8), make([]error, 8)
+	var wg sync.WaitGroup
+	for i := range users {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if i%2 == 0 {
+				users[i], errs[i] = p.Lookup("alice")
+			} else {
+				users[i], errs[i] = p.LookupID("10")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, u := range users {
+		noError(t, errs[i])
+		assertEqual(t, []string{"1", "2", "3"}, u.GroupIDs)
+	}
+
+	// provider state must stay untouched
+	assertEqual(t, []string{"3", "1", "2", "1"}, groups)
+	assertEqual(t, "", p.Users[10].Uid)
+}